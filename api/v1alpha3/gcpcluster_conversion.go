@@ -32,6 +32,25 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		return err
 	}
 
+	// Manually restore data.
+	restored := &v1beta1.GCPCluster{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Network.HostProject = restored.Spec.Network.HostProject
+	dst.Spec.Network.FirewallRules = restored.Spec.Network.FirewallRules
+	dst.Spec.LoadBalancer = restored.Spec.LoadBalancer
+	dst.Spec.IdentityRef = restored.Spec.IdentityRef
+	dst.Spec.Bastion = restored.Spec.Bastion
+	dst.Spec.IAP = restored.Spec.IAP
+	dst.Status.Network.Subnets = restored.Status.Network.Subnets
+	dst.Status.Network.APIServerSecurityPolicy = restored.Status.Network.APIServerSecurityPolicy
+	dst.Status.Network.AdditionalPortForwardingRules = restored.Status.Network.AdditionalPortForwardingRules
+	dst.Status.Bastion = restored.Status.Bastion
+	dst.Status.IAPEndpoint = restored.Status.IAPEndpoint
+	dst.Status.Conditions = restored.Status.Conditions
+
 	return nil
 }
 
@@ -124,3 +143,8 @@ func Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.NetworkSpec
 
 	return nil
 }
+
+// Convert_v1beta1_Network_To_v1alpha3_Network.
+func Convert_v1beta1_Network_To_v1alpha3_Network(in *v1beta1.Network, out *Network, s apiconversion.Scope) error { //nolint
+	return autoConvert_v1beta1_Network_To_v1alpha3_Network(in, out, s)
+}
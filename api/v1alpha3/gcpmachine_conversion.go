@@ -42,6 +42,19 @@ func (src *GCPMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.IPForwarding = restored.Spec.IPForwarding
 	}
 
+	dst.Spec.ProvisioningModel = restored.Spec.ProvisioningModel
+	dst.Spec.InstanceTerminationAction = restored.Spec.InstanceTerminationAction
+	dst.Spec.MaxRunDuration = restored.Spec.MaxRunDuration
+	dst.Spec.GuestAccelerators = restored.Spec.GuestAccelerators
+	dst.Spec.ShieldedInstanceConfig = restored.Spec.ShieldedInstanceConfig
+	dst.Spec.ConfidentialCompute = restored.Spec.ConfidentialCompute
+	dst.Spec.RootDiskEncryptionKey = restored.Spec.RootDiskEncryptionKey
+	restoreAdditionalDisksEncryptionKeys(dst.Spec.AdditionalDisks, restored.Spec.AdditionalDisks)
+	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.Accelerators = restored.Status.Accelerators
+	dst.Status.ShieldedInstanceConfig = restored.Status.ShieldedInstanceConfig
+	dst.Status.ConfidentialCompute = restored.Status.ConfidentialCompute
+
 	return nil
 }
 
@@ -76,3 +89,24 @@ func (dst *GCPMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 func Convert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.GCPMachineSpec, out *GCPMachineSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in, out, s)
 }
+
+// Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in, out, s)
+}
+
+// Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec is an autogenerated conversion function.
+func Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in, out, s)
+}
+
+// restoreAdditionalDisksEncryptionKeys restores the encryption keys of the additional disks lost on down-conversion.
+func restoreAdditionalDisksEncryptionKeys(dst, restored []v1beta1.AttachedDiskSpec) {
+	if len(dst) != len(restored) {
+		return
+	}
+
+	for i := range dst {
+		dst[i].EncryptionKey = restored[i].EncryptionKey
+	}
+}
@@ -17,20 +17,56 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
 // ConvertTo converts this GCPCluster to the Hub version (v1beta1).
 func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 	dst := dstRaw.(*infrav1beta1.GCPCluster)
-	return Convert_v1alpha4_GCPCluster_To_v1beta1_GCPCluster(src, dst, nil)
+
+	if err := Convert_v1alpha4_GCPCluster_To_v1beta1_GCPCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1beta1.GCPCluster{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Network.HostProject = restored.Spec.Network.HostProject
+	dst.Spec.Network.FirewallRules = restored.Spec.Network.FirewallRules
+	dst.Spec.LoadBalancer = restored.Spec.LoadBalancer
+	dst.Spec.IdentityRef = restored.Spec.IdentityRef
+	dst.Spec.Bastion = restored.Spec.Bastion
+	dst.Spec.IAP = restored.Spec.IAP
+	dst.Status.Network.Subnets = restored.Status.Network.Subnets
+	dst.Status.Network.APIServerSecurityPolicy = restored.Status.Network.APIServerSecurityPolicy
+	dst.Status.Network.AdditionalPortForwardingRules = restored.Status.Network.AdditionalPortForwardingRules
+	dst.Status.Bastion = restored.Status.Bastion
+	dst.Status.IAPEndpoint = restored.Status.IAPEndpoint
+	dst.Status.Conditions = restored.Status.Conditions
+
+	return nil
 }
 
 // ConvertFrom converts from the Hub version (v1beta1) to this version.
 func (dst *GCPCluster) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 	src := srcRaw.(*infrav1beta1.GCPCluster)
-	return Convert_v1beta1_GCPCluster_To_v1alpha4_GCPCluster(src, dst, nil)
+
+	if err := Convert_v1beta1_GCPCluster_To_v1alpha4_GCPCluster(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion.
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 // ConvertTo converts this GCPClusterList to the Hub version (v1beta1).
@@ -44,3 +80,23 @@ func (dst *GCPClusterList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 	src := srcRaw.(*infrav1beta1.GCPClusterList)
 	return Convert_v1beta1_GCPClusterList_To_v1alpha4_GCPClusterList(src, dst, nil)
 }
+
+// Convert_v1beta1_Network_To_v1alpha4_Network converts from the Hub version (v1beta1) of the Network to this version.
+func Convert_v1beta1_Network_To_v1alpha4_Network(in *infrav1beta1.Network, out *Network, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_Network_To_v1alpha4_Network(in, out, s)
+}
+
+// Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec converts from the Hub version (v1beta1) of the NetworkSpec to this version.
+func Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *infrav1beta1.NetworkSpec, out *NetworkSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in, out, s)
+}
+
+// Convert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec converts from the Hub version (v1beta1) of the GCPClusterSpec to this version.
+func Convert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in *infrav1beta1.GCPClusterSpec, out *GCPClusterSpec, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in, out, s)
+}
+
+// Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus converts from the Hub version (v1beta1) of the GCPClusterStatus to this version.
+func Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in *infrav1beta1.GCPClusterStatus, out *GCPClusterStatus, s apiconversion.Scope) error { // nolint
+	return autoConvert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in, out, s)
+}
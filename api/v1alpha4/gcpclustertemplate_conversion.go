@@ -38,6 +38,12 @@ func (src *GCPClusterTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 	}
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
+	dst.Spec.Template.Spec.Network.HostProject = restored.Spec.Template.Spec.Network.HostProject
+	dst.Spec.Template.Spec.Network.FirewallRules = restored.Spec.Template.Spec.Network.FirewallRules
+	dst.Spec.Template.Spec.LoadBalancer = restored.Spec.Template.Spec.LoadBalancer
+	dst.Spec.Template.Spec.IdentityRef = restored.Spec.Template.Spec.IdentityRef
+	dst.Spec.Template.Spec.Bastion = restored.Spec.Template.Spec.Bastion
+	dst.Spec.Template.Spec.IAP = restored.Spec.Template.Spec.IAP
 
 	return nil
 }
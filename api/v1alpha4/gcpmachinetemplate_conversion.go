@@ -43,6 +43,15 @@ func (src *GCPMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 		dst.Spec.Template.Spec.IPForwarding = restored.Spec.Template.Spec.IPForwarding
 	}
 
+	dst.Spec.Template.Spec.ProvisioningModel = restored.Spec.Template.Spec.ProvisioningModel
+	dst.Spec.Template.Spec.InstanceTerminationAction = restored.Spec.Template.Spec.InstanceTerminationAction
+	dst.Spec.Template.Spec.MaxRunDuration = restored.Spec.Template.Spec.MaxRunDuration
+	dst.Spec.Template.Spec.GuestAccelerators = restored.Spec.Template.Spec.GuestAccelerators
+	dst.Spec.Template.Spec.ShieldedInstanceConfig = restored.Spec.Template.Spec.ShieldedInstanceConfig
+	dst.Spec.Template.Spec.ConfidentialCompute = restored.Spec.Template.Spec.ConfidentialCompute
+	dst.Spec.Template.Spec.RootDiskEncryptionKey = restored.Spec.Template.Spec.RootDiskEncryptionKey
+	restoreAdditionalDisksEncryptionKeys(dst.Spec.Template.Spec.AdditionalDisks, restored.Spec.Template.Spec.AdditionalDisks)
+
 	return nil
 }
 
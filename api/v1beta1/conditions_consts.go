@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+const (
+	// NetworkReadyCondition reports on the successful reconciliation of the network, subnetworks and cloud nat router.
+	NetworkReadyCondition clusterv1.ConditionType = "NetworkReady"
+	// NetworkReconciliationFailedReason used when any errors occur during the reconciliation of the network.
+	NetworkReconciliationFailedReason = "NetworkReconciliationFailed"
+)
+
+const (
+	// FirewallRulesReadyCondition reports on the successful reconciliation of the firewall rules.
+	FirewallRulesReadyCondition clusterv1.ConditionType = "FirewallRulesReady"
+	// FirewallRulesReconciliationFailedReason used when any errors occur during the reconciliation of the firewall rules.
+	FirewallRulesReconciliationFailedReason = "FirewallRulesReconciliationFailed"
+)
+
+const (
+	// LoadBalancerReadyCondition reports on the successful reconciliation of the API server load balancer.
+	LoadBalancerReadyCondition clusterv1.ConditionType = "LoadBalancerReady"
+	// LoadBalancerReconciliationFailedReason used when any errors occur during the reconciliation of the load balancer.
+	LoadBalancerReconciliationFailedReason = "LoadBalancerReconciliationFailed"
+	// WaitingForControlPlaneEndpointReason used when the load balancer does not have an address yet.
+	WaitingForControlPlaneEndpointReason = "WaitingForControlPlaneEndpoint"
+)
+
+const (
+	// BastionHostReadyCondition reports on the successful reconciliation of the bastion host.
+	BastionHostReadyCondition clusterv1.ConditionType = "BastionHostReady"
+	// BastionHostReconciliationFailedReason used when any errors occur during the reconciliation of the bastion host.
+	BastionHostReconciliationFailedReason = "BastionHostReconciliationFailed"
+)
+
+const (
+	// FailureDomainsReadyCondition reports on the successful discovery of the zones used as failure domains.
+	FailureDomainsReadyCondition clusterv1.ConditionType = "FailureDomainsReady"
+	// FailureDomainsReconciliationFailedReason used when any errors occur while looking up the zones of the region.
+	FailureDomainsReconciliationFailedReason = "FailureDomainsReconciliationFailed"
+	// FailureDomainsNotFoundReason used when none of the zones of the region can be used as failure domains.
+	FailureDomainsNotFoundReason = "FailureDomainsNotFound"
+)
+
+const (
+	// InstanceReadyCondition reports on current status of the GCE instance. Ready indicates the instance is in a Running state.
+	InstanceReadyCondition clusterv1.ConditionType = "InstanceReady"
+	// InstanceNotFoundReason used when the instance couldn't be retrieved.
+	InstanceNotFoundReason = "InstanceNotFound"
+	// InstanceProvisionFailedReason used for failures during instance provisioning.
+	InstanceProvisionFailedReason = "InstanceProvisionFailed"
+	// InstanceNotReadyReason used when the instance is in a pending state.
+	InstanceNotReadyReason = "InstanceNotReady"
+	// InstanceStoppedReason instance is in a stopped or suspended state.
+	InstanceStoppedReason = "InstanceStopped"
+	// InstanceTerminatedReason instance is in a terminated state.
+	InstanceTerminatedReason = "InstanceTerminated"
+	// InstancePreemptedReason used when a Spot or preemptible instance was reclaimed by GCE.
+	InstancePreemptedReason = "InstancePreempted"
+)
+
+const (
+	// BootstrapDataReadyCondition reports on the availability of the bootstrap data of the machine.
+	BootstrapDataReadyCondition clusterv1.ConditionType = "BootstrapDataReady"
+	// WaitingForBootstrapDataReason used when the bootstrap data secret is not available yet.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+)
+
+const (
+	// ControlPlaneLBRegisteredCondition reports on whether the control plane instance is registered
+	// in the instance group backing the API server load balancer.
+	ControlPlaneLBRegisteredCondition clusterv1.ConditionType = "ControlPlaneLBRegistered"
+	// ControlPlaneLBRegistrationFailedReason used when the instance couldn't be registered in the instance group.
+	ControlPlaneLBRegistrationFailedReason = "ControlPlaneLBRegistrationFailed"
+	// ControlPlaneLBDeregistrationFailedReason used when the instance couldn't be deregistered from the instance group.
+	ControlPlaneLBDeregistrationFailedReason = "ControlPlaneLBDeregistrationFailed"
+	// WaitingForInstanceRunningReason used when the instance is registered only once it is running.
+	WaitingForInstanceRunningReason = "WaitingForInstanceRunning"
+)
+
+const (
+	// QuotaExceededReason used when a GCE operation failed because a project or regional quota was exceeded.
+	QuotaExceededReason = "QuotaExceeded"
+	// ZoneResourcesExhaustedReason used when a GCE operation failed because the zone ran out of the requested resources.
+	ZoneResourcesExhaustedReason = "ZoneResourcesExhausted"
+	// PermissionDeniedReason used when a GCE operation failed because the credentials lack permissions.
+	PermissionDeniedReason = "PermissionDenied"
+	// InvalidConfigurationReason used when a GCE operation was rejected because of an invalid request.
+	InvalidConfigurationReason = "InvalidConfiguration"
+)
+
+const (
+	// InstanceTemplateReadyCondition reports on the successful reconciliation of the instance template of the machine pool.
+	InstanceTemplateReadyCondition clusterv1.ConditionType = "InstanceTemplateReady"
+	// InstanceTemplateReconciliationFailedReason used when any errors occur during the reconciliation of the instance template.
+	InstanceTemplateReconciliationFailedReason = "InstanceTemplateReconciliationFailed"
+)
+
+const (
+	// InstanceGroupReadyCondition reports on the successful reconciliation of the managed instance group of the machine pool.
+	InstanceGroupReadyCondition clusterv1.ConditionType = "InstanceGroupReady"
+	// InstanceGroupReconciliationFailedReason used when any errors occur during the reconciliation of the managed instance group.
+	InstanceGroupReconciliationFailedReason = "InstanceGroupReconciliationFailed"
+	// InstanceGroupUpdatingReason used when the managed instance group is not stable yet, e.g. while rolling
+	// its instances to a new instance template or while scaling.
+	InstanceGroupUpdatingReason = "InstanceGroupUpdating"
+	// WaitingForClusterInfrastructureReason used when the infrastructure of the cluster of the machine pool is not ready yet.
+	WaitingForClusterInfrastructureReason = "WaitingForClusterInfrastructure"
+)
+
+const (
+	// GKEControlPlaneReadyCondition reports on the state of the GKE cluster of the GCPManagedControlPlane.
+	GKEControlPlaneReadyCondition clusterv1.ConditionType = "GKEControlPlaneReady"
+	// GKEControlPlaneCreatingReason used when the GKE cluster is being created.
+	GKEControlPlaneCreatingReason = "GKEControlPlaneCreating"
+	// GKEControlPlaneUpdatingReason used when the GKE cluster is being updated.
+	GKEControlPlaneUpdatingReason = "GKEControlPlaneUpdating"
+	// GKEControlPlaneDeletingReason used when the GKE cluster is being deleted.
+	GKEControlPlaneDeletingReason = "GKEControlPlaneDeleting"
+	// GKEControlPlaneErrorReason used when the GKE cluster is in an error or degraded state.
+	GKEControlPlaneErrorReason = "GKEControlPlaneError"
+	// GKEControlPlaneReconciliationFailedReason used when any errors occur during the reconciliation of the GKE cluster.
+	GKEControlPlaneReconciliationFailedReason = "GKEControlPlaneReconciliationFailed"
+	// WaitingForGKEClusterInfrastructureReason used when the GCPManagedCluster network is not ready yet.
+	WaitingForGKEClusterInfrastructureReason = "WaitingForGKEClusterInfrastructure"
+	// WaitingForNodePoolsReason used when a standard GKE cluster has no GCPManagedMachinePool to create its node pools from.
+	WaitingForNodePoolsReason = "WaitingForNodePools"
+)
+
+const (
+	// KubeconfigReadyCondition reports on the availability of the kubeconfig secret of the GKE cluster.
+	KubeconfigReadyCondition clusterv1.ConditionType = "KubeconfigReady"
+	// KubeconfigReconciliationFailedReason used when the kubeconfig secret couldn't be written.
+	KubeconfigReconciliationFailedReason = "KubeconfigReconciliationFailed"
+)
+
+const (
+	// GKENodePoolReadyCondition reports on the state of the GKE node pool of the GCPManagedMachinePool.
+	GKENodePoolReadyCondition clusterv1.ConditionType = "GKENodePoolReady"
+	// GKENodePoolCreatingReason used when the GKE node pool is being created.
+	GKENodePoolCreatingReason = "GKENodePoolCreating"
+	// GKENodePoolUpdatingReason used when the GKE node pool is being updated, upgraded or resized.
+	GKENodePoolUpdatingReason = "GKENodePoolUpdating"
+	// GKENodePoolDeletingReason used when the GKE node pool is being deleted.
+	GKENodePoolDeletingReason = "GKENodePoolDeleting"
+	// GKENodePoolErrorReason used when the GKE node pool is in an error state.
+	GKENodePoolErrorReason = "GKENodePoolError"
+	// GKENodePoolReconciliationFailedReason used when any errors occur during the reconciliation of the GKE node pool.
+	GKENodePoolReconciliationFailedReason = "GKENodePoolReconciliationFailed"
+	// WaitingForGKEControlPlaneReason used when the GKE cluster of the node pool is not running yet.
+	WaitingForGKEControlPlaneReason = "WaitingForGKEControlPlane"
+)
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -25,6 +26,28 @@ const (
 	// ClusterFinalizer allows ReconcileGCPCluster to clean up GCP resources associated with GCPCluster before
 	// removing it from the apiserver.
 	ClusterFinalizer = "gcpcluster.infrastructure.cluster.x-k8s.io"
+
+	// BastionFirewallRuleName is the name of the firewall rule allowing SSH to the bastion host, prefixed by the cluster name.
+	BastionFirewallRuleName = "bastion"
+
+	// BastionNodesFirewallRuleName is the name of the firewall rule allowing SSH from the bastion host to the cluster
+	// machines, prefixed by the cluster name.
+	BastionNodesFirewallRuleName = "bastion-nodes"
+
+	// APIServerFirewallRuleName is the name of the firewall rule allowing the clients of a regional external
+	// load balancer to reach the API server port of the control plane machines, prefixed by the cluster name.
+	APIServerFirewallRuleName = "apiserver"
+
+	// IAPSSHFirewallRuleName is the name of the firewall rule allowing IAP TCP forwarding to port 22 of the cluster
+	// machines, prefixed by the cluster name.
+	IAPSSHFirewallRuleName = "iap-ssh"
+
+	// IAPAPIServerFirewallRuleName is the name of the firewall rule allowing IAP TCP forwarding to the API server port
+	// of the control plane machines, prefixed by the cluster name.
+	IAPAPIServerFirewallRuleName = "iap-apiserver"
+
+	// IAPSourceRange is the range of the addresses IAP uses for TCP forwarding.
+	IAPSourceRange = "35.235.240.0/20"
 )
 
 // GCPClusterSpec defines the desired state of GCPCluster.
@@ -36,6 +59,8 @@ type GCPClusterSpec struct {
 	Region string `json:"region"`
 
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// It is set from the address of the API server load balancer, or provided by the user
+	// when the load balancer type is None.
 	// +optional
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
 
@@ -43,6 +68,10 @@ type GCPClusterSpec struct {
 	// +optional
 	Network NetworkSpec `json:"network"`
 
+	// LoadBalancer contains configuration for the API server load balancer.
+	// +optional
+	LoadBalancer LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
 	// FailureDomains is an optional field which is used to assign selected availability zones to a cluster
 	// FailureDomains if empty, defaults to all the zones in the selected region and if specified would override
 	// the default zones.
@@ -53,6 +82,113 @@ type GCPClusterSpec struct {
 	// ones added by default.
 	// +optional
 	AdditionalLabels Labels `json:"additionalLabels,omitempty"`
+
+	// IdentityRef is a reference to the GCPClusterIdentity holding the credentials
+	// used to manage the cluster resources. When not set, the controller credentials are used.
+	// +optional
+	IdentityRef *GCPIdentityReference `json:"identityRef,omitempty"`
+
+	// Bastion is the configuration of the bastion host giving SSH access to the cluster machines.
+	// The bastion host is not created when not set, and is deleted when removed.
+	// +optional
+	Bastion *BastionSpec `json:"bastion,omitempty"`
+
+	// IAP is the configuration of the Identity-Aware Proxy TCP forwarding access to the cluster machines,
+	// an alternative to public IP addresses and bastion hosts.
+	// +optional
+	IAP *IAPSpec `json:"iap,omitempty"`
+}
+
+// IAPSpec defines the Identity-Aware Proxy TCP forwarding access to the cluster.
+type IAPSpec struct {
+	// SSH allows IAP TCP forwarding to port 22 of the cluster machines. Defaults to true.
+	// +optional
+	SSH *bool `json:"ssh,omitempty"`
+
+	// APIServer allows IAP TCP forwarding to the API server port of the control plane machines. Defaults to true.
+	// +optional
+	APIServer *bool `json:"apiServer,omitempty"`
+
+	// InternalEndpoint creates an internal passthrough load balancer in front of the control plane machines,
+	// so the API server can be reached on a private address of the network, e.g. through an IAP tunnel.
+	// Ignored when the load balancer type is Internal, whose endpoint is already private, or None.
+	// The control plane instance groups can only be shared with the internal load balancer when they
+	// are balanced by connection, so the External load balancer balances them by connection instead
+	// of by utilization while the internal endpoint is enabled.
+	// +optional
+	InternalEndpoint bool `json:"internalEndpoint,omitempty"`
+}
+
+// BastionSpec defines the bastion host of the cluster.
+type BastionSpec struct {
+	// MachineType is the machine type of the bastion instance. Defaults to e2-micro.
+	// +optional
+	MachineType *string `json:"machineType,omitempty"`
+
+	// Image is the full reference to the boot image, or image family, of the bastion instance.
+	// Defaults to the latest Debian 11 image.
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Zone is the zone of the bastion instance. Defaults to the first failure domain of the cluster.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// Subnet is the name of the subnetwork of the bastion instance, in the cluster region.
+	// Networks in "custom" mode require it.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+
+	// PublicIP specifies whether the bastion instance has a public IP address. Defaults to true.
+	// +optional
+	PublicIP *bool `json:"publicIP,omitempty"`
+
+	// AllowedSourceRanges is the list of CIDR ranges allowed to reach the bastion host on port 22.
+	// +kubebuilder:validation:MinItems=1
+	AllowedSourceRanges []string `json:"allowedSourceRanges"`
+
+	// SSHKeys is the list of public SSH keys, in the USERNAME:KEY format, authorized on the bastion host.
+	// OS Login is enabled on the bastion host when no key is set.
+	// +optional
+	SSHKeys []string `json:"sshKeys,omitempty"`
+}
+
+// BastionStatus defines the observed state of the bastion host.
+type BastionStatus struct {
+	// SelfLink is the full reference to the bastion instance.
+	// +optional
+	SelfLink *string `json:"selfLink,omitempty"`
+
+	// InstanceStatus is the status of the bastion instance.
+	// +optional
+	InstanceStatus *InstanceStatus `json:"instanceState,omitempty"`
+
+	// Addresses contains the internal and external addresses of the bastion instance.
+	// +optional
+	Addresses []corev1.NodeAddress `json:"addresses,omitempty"`
+}
+
+// IAPEndpointStatus defines the observed state of the internal API server endpoint.
+type IAPEndpointStatus struct {
+	// Host is the private IP address of the internal API server endpoint.
+	// +optional
+	Host *string `json:"host,omitempty"`
+
+	// Address is the full reference to the internal address of the endpoint.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// HealthCheck is the full reference to the regional health check of the endpoint.
+	// +optional
+	HealthCheck *string `json:"healthCheck,omitempty"`
+
+	// BackendService is the full reference to the regional backend service of the endpoint.
+	// +optional
+	BackendService *string `json:"backendService,omitempty"`
+
+	// ForwardingRule is the full reference to the forwarding rule of the endpoint.
+	// +optional
+	ForwardingRule *string `json:"forwardingRule,omitempty"`
 }
 
 // GCPClusterStatus defines the observed state of GCPCluster.
@@ -60,8 +196,19 @@ type GCPClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Network        Network                  `json:"network,omitempty"`
 
-	// Bastion Instance `json:"bastion,omitempty"`
+	// Bastion is the observed state of the bastion host.
+	// +optional
+	Bastion *BastionStatus `json:"bastion,omitempty"`
+
+	// IAPEndpoint is the observed state of the internal API server endpoint.
+	// +optional
+	IAPEndpoint *IAPEndpointStatus `json:"iapEndpoint,omitempty"`
+
 	Ready bool `json:"ready"`
+
+	// Conditions defines current service state of the GCPCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -70,6 +217,7 @@ type GCPClusterStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPCluster belongs"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Cluster infrastructure is ready for GCE instances"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the readiness of the cluster infrastructure"
 // +kubebuilder:printcolumn:name="Network",type="string",JSONPath=".spec.network.name",description="GCP network the cluster is using"
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.apiEndpoints[0]",description="API Endpoint",priority=1
 
@@ -82,6 +230,16 @@ type GCPCluster struct {
 	Status GCPClusterStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the observations of the operational state of the GCPCluster resource.
+func (r *GCPCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPCluster to the predescribed clusterv1.Conditions.
+func (r *GCPCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // GCPClusterList contains a list of GCPCluster.
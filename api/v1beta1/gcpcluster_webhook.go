@@ -17,11 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"net"
 	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -51,8 +55,18 @@ func (c *GCPCluster) Default() {
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (c *GCPCluster) ValidateCreate() error {
 	clusterlog.Info("validate create", "name", c.Name)
+	allErrs := validateFirewallRules(field.NewPath("spec", "network", "firewallRules"), c.Spec.Network.FirewallRules)
+	allErrs = append(allErrs, c.validateControlPlaneEndpoint()...)
+	allErrs = append(allErrs, validateLoadBalancerHealthCheck(field.NewPath("spec", "loadBalancer", "healthCheck"), c.Spec.LoadBalancer.HealthCheck)...)
+	allErrs = append(allErrs, c.validateSecurityPolicy()...)
+	allErrs = append(allErrs, c.validateAllowedSourceRanges()...)
+	allErrs = append(allErrs, c.validateAdditionalPorts()...)
 
-	return nil
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPCluster").GroupKind(), c.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -75,6 +89,55 @@ func (c *GCPCluster) ValidateUpdate(oldRaw runtime.Object) error {
 		)
 	}
 
+	if !reflect.DeepEqual(c.Spec.Network.HostProject, old.Spec.Network.HostProject) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "HostProject"),
+				c.Spec.Network.HostProject, "field is immutable"),
+		)
+	}
+
+	// The forwarding rules of the passthrough load balancers and the control plane endpoint keep the port they
+	// are created with.
+	if pointer.Int32Deref(c.Spec.Network.LoadBalancerBackendPort, 6443) != pointer.Int32Deref(old.Spec.Network.LoadBalancerBackendPort, 6443) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "LoadBalancerBackendPort"),
+				c.Spec.Network.LoadBalancerBackendPort, "field is immutable"),
+		)
+	}
+
+	if loadBalancerType(c.Spec.LoadBalancer) != loadBalancerType(old.Spec.LoadBalancer) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "LoadBalancerType"),
+				c.Spec.LoadBalancer.LoadBalancerType, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.LoadBalancer.ReservedAddress, old.Spec.LoadBalancer.ReservedAddress) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "ReservedAddress"),
+				c.Spec.LoadBalancer.ReservedAddress, "field is immutable"),
+		)
+	}
+
+	if c.Spec.Bastion != nil && old.Spec.Bastion != nil {
+		// The bastion host is recreated when removed and added back, only its firewall rule is updated in place.
+		bastion := c.Spec.Bastion.DeepCopy()
+		bastion.AllowedSourceRanges = old.Spec.Bastion.AllowedSourceRanges
+		if !reflect.DeepEqual(bastion, old.Spec.Bastion) {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "bastion"),
+					c.Spec.Bastion, "field is immutable, except allowedSourceRanges"),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, validateFirewallRules(field.NewPath("spec", "network", "firewallRules"), c.Spec.Network.FirewallRules)...)
+	allErrs = append(allErrs, c.validateControlPlaneEndpoint()...)
+	allErrs = append(allErrs, validateLoadBalancerHealthCheck(field.NewPath("spec", "loadBalancer", "healthCheck"), c.Spec.LoadBalancer.HealthCheck)...)
+	allErrs = append(allErrs, c.validateSecurityPolicy()...)
+	allErrs = append(allErrs, c.validateAllowedSourceRanges()...)
+	allErrs = append(allErrs, c.validateAdditionalPorts()...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -88,3 +151,210 @@ func (c *GCPCluster) ValidateDelete() error {
 
 	return nil
 }
+
+// loadBalancerType returns the type of the load balancer, External when it is not set.
+func loadBalancerType(spec LoadBalancerSpec) LoadBalancerType {
+	if spec.LoadBalancerType == nil {
+		return External
+	}
+
+	return *spec.LoadBalancerType
+}
+
+// validateControlPlaneEndpoint checks the control plane endpoint is provided, and no load balancer address is,
+// when no load balancer is created.
+func (c *GCPCluster) validateControlPlaneEndpoint() field.ErrorList {
+	var allErrs field.ErrorList
+	if t := c.Spec.LoadBalancer.LoadBalancerType; t == nil || *t != None {
+		return allErrs
+	}
+
+	if c.Spec.LoadBalancer.ReservedAddress != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "loadBalancer", "reservedAddress"), "reservedAddress is not supported when the load balancer type is None"))
+	}
+
+	fldPath := field.NewPath("spec", "controlPlaneEndpoint")
+	if c.Spec.ControlPlaneEndpoint.Host == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("host"), "the control plane endpoint is required when the load balancer type is None"))
+	}
+	if c.Spec.ControlPlaneEndpoint.Port == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("port"), "the control plane endpoint is required when the load balancer type is None"))
+	}
+
+	return allErrs
+}
+
+// validateLoadBalancerHealthCheck checks the request path is only set for HTTPS health checks, and probes time out
+// before the next one starts.
+func validateLoadBalancerHealthCheck(fldPath *field.Path, healthCheck *LoadBalancerHealthCheck) field.ErrorList {
+	var allErrs field.ErrorList
+	if healthCheck == nil {
+		return allErrs
+	}
+
+	if healthCheck.RequestPath != nil && (healthCheck.Protocol == nil || *healthCheck.Protocol != HealthCheckProtocolHTTPS) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("requestPath"), "requestPath is only supported by the HTTPS protocol"))
+	}
+
+	interval := pointer.Int64Deref(healthCheck.CheckIntervalSec, 10)
+	if timeout := pointer.Int64Deref(healthCheck.TimeoutSec, 5); timeout > interval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSec"), timeout, "timeoutSec can't be greater than checkIntervalSec"))
+	}
+
+	return allErrs
+}
+
+// validateSecurityPolicy checks the security policy is either referenced or defined inline, and is only set for
+// the External load balancer type, the only one whose backend service supports Cloud Armor.
+func (c *GCPCluster) validateSecurityPolicy() field.ErrorList {
+	var allErrs field.ErrorList
+	policy := c.Spec.LoadBalancer.SecurityPolicy
+	if policy == nil {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "loadBalancer", "securityPolicy")
+	if t := c.Spec.LoadBalancer.LoadBalancerType; t != nil && *t != External {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "securityPolicy is only supported by the External load balancer type"))
+	}
+
+	switch {
+	case policy.Name != nil && len(policy.AllowedSourceRanges) > 0:
+		allErrs = append(allErrs, field.Invalid(fldPath, policy, "only one of name or allowedSourceRanges can be set"))
+	case policy.Name == nil && len(policy.AllowedSourceRanges) == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "one of name or allowedSourceRanges is required"))
+	}
+
+	for i, cidr := range policy.AllowedSourceRanges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedSourceRanges").Index(i), cidr, "must be a valid CIDR range"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAllowedSourceRanges checks the source ranges allowed to reach the API server are valid CIDR ranges, and are
+// only set for the passthrough load balancer types, the only ones filtered by a firewall rule.
+func (c *GCPCluster) validateAllowedSourceRanges() field.ErrorList {
+	var allErrs field.ErrorList
+	ranges := c.Spec.LoadBalancer.AllowedSourceRanges
+	if len(ranges) == 0 {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "loadBalancer", "allowedSourceRanges")
+	if t := c.Spec.LoadBalancer.LoadBalancerType; t == nil || !t.IsRegional() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "allowedSourceRanges is only supported by the Internal and RegionalExternal load balancer types"))
+	}
+
+	for i, cidr := range ranges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, "must be a valid CIDR range"))
+		}
+	}
+
+	return allErrs
+}
+
+// reservedAdditionalPortNames are the names of the load balancer resources created by the controller for the API
+// server and its internal endpoint, which share the cluster name prefix of the resources of the additional ports.
+var reservedAdditionalPortNames = map[string]bool{
+	APIServerRoleTagValue:               true,
+	APIServerRoleTagValue + "-internal": true,
+}
+
+// tcpProxyPorts are the ports the global TCP proxy forwarding rules of the External load balancer type accept.
+var tcpProxyPorts = sets.NewInt32(25, 43, 110, 143, 195, 443, 465, 587, 700, 993, 995, 1883, 3389, 5222, 5432, 5671, 5672,
+	5900, 5901, 6379, 8085, 8099, 9092, 9200, 9300)
+
+// validateAdditionalPorts checks the additional ports of the load balancer are unique, don't collide with the
+// resources of the API server, and are only set for the load balancer types exposing them on an external address.
+// The External load balancer type only accepts the ports of the TCP proxy.
+func (c *GCPCluster) validateAdditionalPorts() field.ErrorList {
+	var allErrs field.ErrorList
+	ports := c.Spec.LoadBalancer.AdditionalPorts
+	if len(ports) == 0 {
+		return allErrs
+	}
+
+	fldPath := field.NewPath("spec", "loadBalancer", "additionalPorts")
+	t := c.Spec.LoadBalancer.LoadBalancerType
+	if t != nil && *t != External && *t != RegionalExternal {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "additionalPorts are only supported by the External and RegionalExternal load balancer types"))
+	}
+
+	names := map[string]bool{}
+	numbers := map[int32]bool{}
+	if t != nil && *t == RegionalExternal {
+		// Passthrough load balancers listen on the backend port of the API server.
+		numbers[pointer.Int32Deref(c.Spec.Network.LoadBalancerBackendPort, 6443)] = true
+	}
+
+	for i, port := range ports {
+		if names[port.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), port.Name))
+		}
+		names[port.Name] = true
+
+		if reservedAdditionalPortNames[port.Name] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), port.Name, "name is reserved for the load balancer resources of the API server"))
+		}
+
+		if numbers[port.Port] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("port"), port.Port))
+		}
+		numbers[port.Port] = true
+
+		if (t == nil || *t == External) && !tcpProxyPorts.Has(port.Port) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("port"), port.Port,
+				fmt.Sprintf("the External load balancer type only supports the TCP proxy ports %v", tcpProxyPorts.List())))
+		}
+	}
+
+	return allErrs
+}
+
+// reservedFirewallRuleNames are the names of the firewall rules created by the controller for the cluster features,
+// e.g. the bastion host, which share the name prefix of the user-defined firewall rules.
+var reservedFirewallRuleNames = map[string]bool{
+	BastionFirewallRuleName:      true,
+	BastionNodesFirewallRuleName: true,
+	APIServerFirewallRuleName:    true,
+	IAPSSHFirewallRuleName:       true,
+	IAPAPIServerFirewallRuleName: true,
+}
+
+// validateFirewallRules checks the firewall rules are unique and only set the fields matching their direction.
+func validateFirewallRules(fldPath *field.Path, rules FirewallRules) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		rulePath := fldPath.Index(i)
+		if names[rule.Name] {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("name"), rule.Name))
+		}
+		names[rule.Name] = true
+
+		if reservedFirewallRuleNames[rule.Name] {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("name"), rule.Name, "name is reserved for the firewall rules of the cluster"))
+		}
+
+		if rule.Direction != nil && *rule.Direction == FirewallRuleDirectionEgress {
+			if len(rule.SourceRanges) > 0 || len(rule.SourceTags) > 0 || len(rule.SourceServiceAccounts) > 0 {
+				allErrs = append(allErrs, field.Forbidden(rulePath, "egress rules can't set sourceRanges, sourceTags or sourceServiceAccounts"))
+			}
+		} else if len(rule.DestinationRanges) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rulePath.Child("destinationRanges"), "only applicable to egress rules"))
+		}
+
+		hasTags := len(rule.SourceTags) > 0 || len(rule.TargetTags) > 0
+		hasServiceAccounts := len(rule.SourceServiceAccounts) > 0 || len(rule.TargetServiceAccounts) > 0
+		if hasTags && hasServiceAccounts {
+			allErrs = append(allErrs, field.Forbidden(rulePath, "network tags and service accounts can't be combined"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,515 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestGCPCluster_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	egress := FirewallRuleDirectionEgress
+	https := HealthCheckProtocolHTTPS
+	internal, regionalExternal := Internal, RegionalExternal
+
+	tests := []struct {
+		name    string
+		cluster *GCPCluster
+		wantErr bool
+	}{
+		{
+			name: "GCPCluster with firewall rules",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						FirewallRules: FirewallRules{
+							{
+								Name:         "ssh",
+								Protocols:    []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}},
+								SourceRanges: []string{"10.0.0.0/8"},
+							},
+							{
+								Name:              "deny-egress",
+								Direction:         &egress,
+								Protocols:         []FirewallRuleProtocol{{Protocol: "all"}},
+								DestinationRanges: []string{"0.0.0.0/0"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with duplicate firewall rules",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						FirewallRules: FirewallRules{
+							{Name: "ssh", Protocols: []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}}},
+							{Name: "ssh", Protocols: []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"2222"}}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with egress firewall rule with source ranges",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						FirewallRules: FirewallRules{
+							{
+								Name:         "egress",
+								Direction:    &egress,
+								Protocols:    []FirewallRuleProtocol{{Protocol: "all"}},
+								SourceRanges: []string{"10.0.0.0/8"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with firewall rule mixing network tags and service accounts",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						FirewallRules: FirewallRules{
+							{
+								Name:                  "nodes",
+								Protocols:             []FirewallRuleProtocol{{Protocol: "tcp"}},
+								SourceTags:            []string{"my-cluster-node"},
+								TargetServiceAccounts: []string{"nodes@my-proj.iam.gserviceaccount.com"},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with firewall rules using reserved names",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{
+						FirewallRules: FirewallRules{
+							{Name: IAPSSHFirewallRuleName, Protocols: []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}}},
+							{Name: BastionFirewallRuleName, Protocols: []FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with HTTPS health check",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						HealthCheck: &LoadBalancerHealthCheck{
+							Protocol:         &https,
+							RequestPath:      pointer.String("/livez"),
+							CheckIntervalSec: pointer.Int64(5),
+							TimeoutSec:       pointer.Int64(5),
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with SSL health check and request path",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						HealthCheck: &LoadBalancerHealthCheck{RequestPath: pointer.String("/readyz")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with health check timeout greater than its interval",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						HealthCheck: &LoadBalancerHealthCheck{CheckIntervalSec: pointer.Int64(2)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with security policy allow-list",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						SecurityPolicy: &SecurityPolicySpec{AllowedSourceRanges: []string{"203.0.113.0/24"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with security policy name and allow-list",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						SecurityPolicy: &SecurityPolicySpec{
+							Name:                pointer.String("my-policy"),
+							AllowedSourceRanges: []string{"203.0.113.0/24"},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with security policy and internal load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType: &internal,
+						SecurityPolicy:   &SecurityPolicySpec{Name: pointer.String("my-policy")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with invalid security policy source range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						SecurityPolicy: &SecurityPolicySpec{AllowedSourceRanges: []string{"203.0.113.0"}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with allowed source ranges and regional external load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType:    &regionalExternal,
+						AllowedSourceRanges: []string{"203.0.113.0/24"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with allowed source ranges and internal load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType:    &internal,
+						AllowedSourceRanges: []string{"10.0.0.0/8"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with allowed source ranges and external load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AllowedSourceRanges: []string{"203.0.113.0/24"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with invalid allowed source range",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType:    &regionalExternal,
+						AllowedSourceRanges: []string{"203.0.113.0"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with additional ports",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AdditionalPorts: []LoadBalancerPort{{Name: "postgres", Port: 5432}, {Name: "mqtt", Port: 1883}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with additional port not supported by the TCP proxy",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AdditionalPorts: []LoadBalancerPort{{Name: "konnectivity", Port: 8132}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with additional port and regional external load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType: &regionalExternal,
+						AdditionalPorts:  []LoadBalancerPort{{Name: "konnectivity", Port: 8132}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with additional ports using the same port",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AdditionalPorts: []LoadBalancerPort{{Name: "postgres", Port: 5432}, {Name: "registry", Port: 5432}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with additional port named after the API server",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AdditionalPorts: []LoadBalancerPort{{Name: APIServerRoleTagValue, Port: 5432}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with additional port named after the internal API server endpoint",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						AdditionalPorts: []LoadBalancerPort{{Name: "apiserver-internal", Port: 5432}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with additional ports and internal load balancer",
+			cluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{
+						LoadBalancerType: &internal,
+						AdditionalPorts:  []LoadBalancerPort{{Name: "konnectivity", Port: 8132}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.cluster.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGCPCluster_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	external, internal := External, Internal
+
+	tests := []struct {
+		name       string
+		oldCluster *GCPCluster
+		newCluster *GCPCluster
+		wantErr    bool
+	}{
+		{
+			name: "GCPCluster with updated bastion allowed source ranges",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Bastion: &BastionSpec{AllowedSourceRanges: []string{"203.0.113.0/24"}},
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Bastion: &BastionSpec{AllowedSourceRanges: []string{"198.51.100.0/24"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPCluster with updated bastion machine type",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Bastion: &BastionSpec{AllowedSourceRanges: []string{"203.0.113.0/24"}},
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Bastion: &BastionSpec{
+						MachineType:         pointer.String("e2-small"),
+						AllowedSourceRanges: []string{"203.0.113.0/24"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with updated reserved address",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{ReservedAddress: pointer.String("my-address")},
+				},
+			},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{ReservedAddress: pointer.String("203.0.113.20")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "GCPCluster with load balancer backend port set to the default",
+			oldCluster: &GCPCluster{},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{LoadBalancerBackendPort: pointer.Int32(6443)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "GCPCluster with updated load balancer backend port",
+			oldCluster: &GCPCluster{},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Network: NetworkSpec{LoadBalancerBackendPort: pointer.Int32(8443)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:       "GCPCluster with load balancer type set to the default",
+			oldCluster: &GCPCluster{},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{LoadBalancerType: &external},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:       "GCPCluster with updated load balancer type",
+			oldCluster: &GCPCluster{},
+			newCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					LoadBalancer: LoadBalancerSpec{LoadBalancerType: &internal},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPCluster with removed bastion",
+			oldCluster: &GCPCluster{
+				Spec: GCPClusterSpec{
+					Bastion: &BastionSpec{AllowedSourceRanges: []string{"203.0.113.0/24"}},
+				},
+			},
+			newCluster: &GCPCluster{},
+			wantErr:    false,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.newCluster.ValidateUpdate(test.oldCluster)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGCPCluster_ValidateCreate_controlPlaneEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	none := None
+
+	tests := []struct {
+		name     string
+		endpoint clusterv1.APIEndpoint
+		wantErr  bool
+	}{
+		{
+			name:     "GCPCluster without load balancer and with control plane endpoint",
+			endpoint: clusterv1.APIEndpoint{Host: "10.0.0.100", Port: 6443},
+			wantErr:  false,
+		},
+		{
+			name:     "GCPCluster without load balancer and control plane endpoint",
+			endpoint: clusterv1.APIEndpoint{},
+			wantErr:  true,
+		},
+		{
+			name:     "GCPCluster without load balancer and control plane endpoint port",
+			endpoint: clusterv1.APIEndpoint{Host: "10.0.0.100"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			cluster := &GCPCluster{
+				Spec: GCPClusterSpec{
+					ControlPlaneEndpoint: test.endpoint,
+					LoadBalancer:         LoadBalancerSpec{LoadBalancerType: &none},
+				},
+			}
+			err := cluster.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GCPClusterIdentityKind is the kind of the GCPClusterIdentity.
+	GCPClusterIdentityKind = "GCPClusterIdentity"
+
+	// GCPClusterIdentitySecretKey is the key in the referenced secret holding the service account key.
+	GCPClusterIdentitySecretKey = "credentials"
+)
+
+// GCPIdentityReference specifies the identity used to manage the cluster.
+type GCPIdentityReference struct {
+	// Name of the GCPClusterIdentity to be used.
+	Name string `json:"name"`
+}
+
+// AllowedNamespaces defines the namespaces the identity can be used from.
+type AllowedNamespaces struct {
+	// NamespaceList is a list of namespaces that GCPClusters can
+	// use this identity from.
+	// +optional
+	// +nullable
+	NamespaceList []string `json:"list,omitempty"`
+
+	// Selector is a selector of namespaces that GCPClusters can
+	// use this identity from.
+	// A nil or empty selector selects no namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// GCPClusterIdentitySpec defines the desired state of GCPClusterIdentity.
+type GCPClusterIdentitySpec struct {
+	// AllowedNamespaces is used to identify which namespaces are allowed to use the identity from.
+	// An empty allowedNamespaces object indicates that GCPClusters can use this identity from any namespace.
+	// If this object is nil, no namespaces will be allowed.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+
+	// SecretRef is a reference to the secret holding the JSON key of a service account
+	// under the "credentials" key. When not set, the controller credentials are used.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// ImpersonateServiceAccount is the email of a service account to impersonate
+	// using the credentials from SecretRef, or the controller credentials when SecretRef is not set.
+	// +optional
+	ImpersonateServiceAccount *string `json:"impersonateServiceAccount,omitempty"`
+
+	// Delegates is the chain of service accounts with the token creator role used to
+	// impersonate ImpersonateServiceAccount.
+	// +optional
+	Delegates []string `json:"delegates,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpclusteridentities,scope=Cluster,categories=cluster-api,shortName=gcpci
+// +kubebuilder:storageversion
+
+// GCPClusterIdentity is the Schema for the gcpclusteridentities API.
+// It represents the GCP credentials a GCPCluster uses to manage its resources.
+type GCPClusterIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCPClusterIdentitySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPClusterIdentityList contains a list of GCPClusterIdentity.
+type GCPClusterIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPClusterIdentity `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPClusterIdentity{}, &GCPClusterIdentityList{})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var gcpclusteridentitylog = logf.Log.WithName("gcpclusteridentity-resource")
+
+func (r *GCPClusterIdentity) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpclusteridentity,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=gcpclusteridentities,versions=v1beta1,name=validation.gcpclusteridentity.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &GCPClusterIdentity{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPClusterIdentity) ValidateCreate() error {
+	gcpclusteridentitylog.Info("validate create", "name", r.Name)
+
+	return r.validateSpec()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPClusterIdentity) ValidateUpdate(_ runtime.Object) error {
+	gcpclusteridentitylog.Info("validate update", "name", r.Name)
+
+	return r.validateSpec()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPClusterIdentity) ValidateDelete() error {
+	gcpclusteridentitylog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *GCPClusterIdentity) validateSpec() error {
+	var allErrs field.ErrorList
+
+	if r.Spec.SecretRef == nil && r.Spec.ImpersonateServiceAccount == nil {
+		allErrs = append(allErrs,
+			field.Required(field.NewPath("spec", "secretRef"),
+				"either secretRef or impersonateServiceAccount must be set"),
+		)
+	}
+
+	if r.Spec.SecretRef != nil && (r.Spec.SecretRef.Name == "" || r.Spec.SecretRef.Namespace == "") {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "secretRef"),
+				r.Spec.SecretRef, "name and namespace must be set"),
+		)
+	}
+
+	if r.Spec.ImpersonateServiceAccount == nil && len(r.Spec.Delegates) > 0 {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "delegates"),
+				r.Spec.Delegates, "delegates require impersonateServiceAccount to be set"),
+		)
+	}
+
+	if r.Spec.AllowedNamespaces != nil && r.Spec.AllowedNamespaces.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(r.Spec.AllowedNamespaces.Selector); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "allowedNamespaces", "selector"),
+					r.Spec.AllowedNamespaces.Selector, err.Error()),
+			)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind(GCPClusterIdentityKind).GroupKind(), r.Name, allErrs)
+}
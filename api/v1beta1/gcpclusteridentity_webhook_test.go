@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPClusterIdentity_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		identity *GCPClusterIdentity
+		wantErr  bool
+	}{
+		{
+			name: "GCPClusterIdentity with secret reference",
+			identity: &GCPClusterIdentity{
+				Spec: GCPClusterIdentitySpec{
+					SecretRef: &corev1.SecretReference{Name: "creds", Namespace: "capg-system"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPClusterIdentity with impersonated service account",
+			identity: &GCPClusterIdentity{
+				Spec: GCPClusterIdentitySpec{
+					ImpersonateServiceAccount: pointer.String("capg@my-proj.iam.gserviceaccount.com"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPClusterIdentity without credentials",
+			identity: &GCPClusterIdentity{
+				Spec: GCPClusterIdentitySpec{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPClusterIdentity with secret reference missing namespace",
+			identity: &GCPClusterIdentity{
+				Spec: GCPClusterIdentitySpec{
+					SecretRef: &corev1.SecretReference{Name: "creds"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPClusterIdentity with delegates but no impersonated service account",
+			identity: &GCPClusterIdentity{
+				Spec: GCPClusterIdentitySpec{
+					SecretRef: &corev1.SecretReference{Name: "creds", Namespace: "capg-system"},
+					Delegates: []string{"delegate@my-proj.iam.gserviceaccount.com"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.identity.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
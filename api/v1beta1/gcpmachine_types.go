@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
@@ -54,6 +55,63 @@ type AttachedDiskSpec struct {
 	// Defaults to 30GB. For "local-ssd" size is always 375GB.
 	// +optional
 	Size *int64 `json:"size,omitempty"`
+	// EncryptionKey defines the KMS key or the customer-supplied key used to encrypt the disk.
+	// Not supported for "local-ssd" disks.
+	// +optional
+	EncryptionKey *CustomerEncryptionKey `json:"encryptionKey,omitempty"`
+}
+
+// KeyType is a type for disk encryption.
+type KeyType string
+
+const (
+	// CustomerManagedKey (CMEK) references an encryption key stored in Google Cloud KMS.
+	CustomerManagedKey KeyType = "Managed"
+	// CustomerSuppliedKey (CSEK) specifies an encryption key stored in a Kubernetes Secret.
+	CustomerSuppliedKey KeyType = "Supplied"
+)
+
+// ManagedKey is a reference to a key managed by the Cloud Key Management Service.
+type ManagedKey struct {
+	// KMSKeyName is the name of the encryption key that is stored in Google Cloud KMS. For example:
+	// "projects/<kms_project>/locations/<region>/keyRings/<key_ring>/cryptoKeys/<key>"
+	// +kubebuilder:validation:Pattern=`projects\/[-_[A-Za-z0-9]+\/locations\/[-_[A-Za-z0-9]+\/keyRings\/[-_[A-Za-z0-9]+\/cryptoKeys\/[-_[A-Za-z0-9]+`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=160
+	KMSKeyName string `json:"kmsKeyName"`
+}
+
+// SuppliedKey is a reference to a customer-supplied encryption key stored in a Secret of the GCPMachine namespace.
+// Exactly one of RawKey or RSAEncryptedKey must be set.
+type SuppliedKey struct {
+	// RawKey references the Secret key holding a 256-bit customer-supplied encryption key,
+	// encoded in RFC 4648 base64.
+	// +optional
+	RawKey *corev1.SecretKeySelector `json:"rawKey,omitempty"`
+	// RSAEncryptedKey references the Secret key holding a 256-bit customer-supplied encryption key,
+	// wrapped with the Google public certificate and encoded in RFC 4648 base64.
+	// +optional
+	RSAEncryptedKey *corev1.SecretKeySelector `json:"rsaEncryptedKey,omitempty"`
+}
+
+// CustomerEncryptionKey supports both Customer-Managed or Customer-Supplied encryption keys .
+type CustomerEncryptionKey struct {
+	// KeyType is the type of encryption key. Must be either Managed, aka Customer-Managed Encryption Key (CMEK) or
+	// Supplied, aka Customer-Supplied EncryptionKey (CSEK).
+	// +kubebuilder:validation:Enum=Managed;Supplied
+	KeyType KeyType `json:"keyType"`
+	// KMSKeyServiceAccount is the service account being used for the encryption request for the given KMS key.
+	// If absent, the Compute Engine default service account is used. For example:
+	// "service-<project_number>@compute-system.iam.gserviceaccount.com".
+	// Only allowed with the Managed key type.
+	// +optional
+	KMSKeyServiceAccount *string `json:"kmsKeyServiceAccount,omitempty"`
+	// ManagedKey references the KMS key, required when KeyType is Managed.
+	// +optional
+	ManagedKey *ManagedKey `json:"managedKey,omitempty"`
+	// SuppliedKey references the customer-supplied key, required when KeyType is Supplied.
+	// +optional
+	SuppliedKey *SuppliedKey `json:"suppliedKey,omitempty"`
 }
 
 // IPForwarding represents the IP forwarding configuration for the GCP machine.
@@ -66,6 +124,102 @@ const (
 	IPForwardingDisabled IPForwarding = "Disabled"
 )
 
+// ProvisioningModel is a type for Spot VM enablement.
+type ProvisioningModel string
+
+const (
+	// ProvisioningModelStandard specifies the VM type to NOT be Spot.
+	ProvisioningModelStandard ProvisioningModel = "Standard"
+	// ProvisioningModelSpot specifies the VM type to be Spot.
+	ProvisioningModelSpot ProvisioningModel = "Spot"
+)
+
+// InstanceTerminationAction is the action taken by GCE when a Spot VM is reclaimed.
+type InstanceTerminationAction string
+
+const (
+	// InstanceTerminationActionStop stops the instance when it is reclaimed.
+	InstanceTerminationActionStop InstanceTerminationAction = "Stop"
+	// InstanceTerminationActionDelete deletes the instance when it is reclaimed.
+	InstanceTerminationActionDelete InstanceTerminationAction = "Delete"
+)
+
+// SecureBootPolicy represents the secure boot configuration for the GCP machine.
+type SecureBootPolicy string
+
+const (
+	// SecureBootPolicyEnabled enables the secure boot configuration for the GCP machine.
+	SecureBootPolicyEnabled SecureBootPolicy = "Enabled"
+	// SecureBootPolicyDisabled disables the secure boot configuration for the GCP machine.
+	SecureBootPolicyDisabled SecureBootPolicy = "Disabled"
+)
+
+// VirtualizedTrustedPlatformModulePolicy represents the virtualized trusted platform module configuration for the GCP machine.
+type VirtualizedTrustedPlatformModulePolicy string
+
+const (
+	// VirtualizedTrustedPlatformModulePolicyEnabled enables the virtualized trusted platform module for the GCP machine.
+	VirtualizedTrustedPlatformModulePolicyEnabled VirtualizedTrustedPlatformModulePolicy = "Enabled"
+	// VirtualizedTrustedPlatformModulePolicyDisabled disables the virtualized trusted platform module for the GCP machine.
+	VirtualizedTrustedPlatformModulePolicyDisabled VirtualizedTrustedPlatformModulePolicy = "Disabled"
+)
+
+// IntegrityMonitoringPolicy represents the integrity monitoring configuration for the GCP machine.
+type IntegrityMonitoringPolicy string
+
+const (
+	// IntegrityMonitoringPolicyEnabled enables integrity monitoring for the GCP machine.
+	IntegrityMonitoringPolicyEnabled IntegrityMonitoringPolicy = "Enabled"
+	// IntegrityMonitoringPolicyDisabled disables integrity monitoring for the GCP machine.
+	IntegrityMonitoringPolicyDisabled IntegrityMonitoringPolicy = "Disabled"
+)
+
+// GCPShieldedInstanceConfig describes the shielded VM configuration of the instance on GCP.
+// Shielded VM configuration allow users to enable and disable Secure Boot, vTPM, and Integrity Monitoring.
+type GCPShieldedInstanceConfig struct {
+	// SecureBoot Defines whether the instance should have secure boot enabled.
+	// Secure Boot verify the digital signature of all boot components, and halting the boot process if signature verification fails.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Disabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	SecureBoot SecureBootPolicy `json:"secureBoot,omitempty"`
+
+	// VirtualizedTrustedPlatformModule enable virtualized trusted platform module measurements to create a known good boot integrity policy baseline.
+	// The integrity policy baseline is used for comparison with measurements from subsequent VM boots to determine if anything has changed.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	VirtualizedTrustedPlatformModule VirtualizedTrustedPlatformModulePolicy `json:"virtualizedTrustedPlatformModule,omitempty"`
+
+	// IntegrityMonitoring determines whether the instance should have integrity monitoring that verify the runtime boot integrity.
+	// Compares the most recent boot measurements to the integrity policy baseline and return
+	// a pair of pass/fail results depending on whether they match or not.
+	// Requires the virtualized trusted platform module to be enabled.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Enabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	IntegrityMonitoring IntegrityMonitoringPolicy `json:"integrityMonitoring,omitempty"`
+}
+
+// ConfidentialComputePolicy represents the confidential compute configuration for the GCP machine.
+type ConfidentialComputePolicy string
+
+const (
+	// ConfidentialComputePolicyEnabled enables confidential compute for the GCP machine.
+	ConfidentialComputePolicyEnabled ConfidentialComputePolicy = "Enabled"
+	// ConfidentialComputePolicyDisabled disables confidential compute for the GCP machine.
+	ConfidentialComputePolicyDisabled ConfidentialComputePolicy = "Disabled"
+)
+
+// Accelerator is a specification of the type and number of accelerator cards attached to the instance.
+type Accelerator struct {
+	// Type is the accelerator type resource name, not a full URL. Example: nvidia-tesla-t4
+	Type string `json:"type"`
+	// Count is the number of accelerator cards of this type exposed to the instance.
+	// +kubebuilder:validation:Minimum=1
+	Count int64 `json:"count"`
+}
+
 // GCPMachineSpec defines the desired state of GCPMachine.
 type GCPMachineSpec struct {
 	// InstanceType is the type of instance to create. Example: n1.standard-2
@@ -130,6 +284,10 @@ type GCPMachineSpec struct {
 	// +optional
 	RootDeviceType *DiskType `json:"rootDeviceType,omitempty"`
 
+	// RootDiskEncryptionKey defines the KMS key or the customer-supplied key used to encrypt the root disk.
+	// +optional
+	RootDiskEncryptionKey *CustomerEncryptionKey `json:"rootDiskEncryptionKey,omitempty"`
+
 	// AdditionalDisks are optional non-boot attached disks.
 	// +optional
 	AdditionalDisks []AttachedDiskSpec `json:"additionalDisks,omitempty"`
@@ -143,6 +301,49 @@ type GCPMachineSpec struct {
 	// +optional
 	Preemptible bool `json:"preemptible,omitempty"`
 
+	// ProvisioningModel defines if instance is spot.
+	// If set to "Spot", the instance can be reclaimed by GCE at any time and is never restarted automatically.
+	// Can not be combined with Preemptible.
+	// Defaults to "Standard".
+	// +kubebuilder:validation:Enum=Standard;Spot
+	// +optional
+	ProvisioningModel *ProvisioningModel `json:"provisioningModel,omitempty"`
+
+	// InstanceTerminationAction is the action taken by GCE when the Spot instance is reclaimed.
+	// Only allowed when ProvisioningModel is "Spot". Defaults to "Stop".
+	// The reclaimed instance of a GCPMachine is never recreated, whether it was stopped or deleted:
+	// the machine is reported as preempted and left to the MachineHealthCheck remediation.
+	// +kubebuilder:validation:Enum=Stop;Delete
+	// +optional
+	InstanceTerminationAction *InstanceTerminationAction `json:"instanceTerminationAction,omitempty"`
+
+	// MaxRunDuration is the duration after which GCE reclaims the Spot instance, applying its InstanceTerminationAction.
+	// Only allowed when ProvisioningModel is "Spot". Must be between 30 seconds and 120 days.
+	// +optional
+	MaxRunDuration *metav1.Duration `json:"maxRunDuration,omitempty"`
+
+	// GuestAccelerators is a list of the type and count of accelerator cards attached to the instance.
+	// Instances with accelerators can not be live migrated, their onHostMaintenance policy is always
+	// set to TERMINATE.
+	// +optional
+	GuestAccelerators []Accelerator `json:"guestAccelerators,omitempty"`
+
+	// ShieldedInstanceConfig is the Shielded VM configuration for this machine.
+	// Secure Boot and vTPM require an image supporting UEFI. The image is not validated on admission,
+	// GCE rejects the instance when it does not support them.
+	// +optional
+	ShieldedInstanceConfig *GCPShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
+
+	// ConfidentialCompute Defines whether the instance should have confidential compute enabled.
+	// If enabled, the instance type must belong to a machine series supporting AMD SEV (n2d or c2d),
+	// the image must support confidential computing, and onHostMaintenance is set to TERMINATE. Only the
+	// machine series is validated on admission, GCE rejects the instance when the image does not support it.
+	// Can not be combined with GuestAccelerators.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is Disabled.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	ConfidentialCompute *ConfidentialComputePolicy `json:"confidentialCompute,omitempty"`
+
 	// IPForwarding Allows this instance to send and receive packets with non-matching destination or source IPs.
 	// This is required if you plan to use this instance to forward routes. Defaults to enabled.
 	// +kubebuilder:validation:Enum=Enabled;Disabled
@@ -172,6 +373,18 @@ type GCPMachineStatus struct {
 	// +optional
 	InstanceStatus *InstanceStatus `json:"instanceState,omitempty"`
 
+	// Accelerators contains the accelerator cards attached to the GCP instance.
+	// +optional
+	Accelerators []Accelerator `json:"accelerators,omitempty"`
+
+	// ShieldedInstanceConfig is the effective Shielded VM configuration of the GCP instance.
+	// +optional
+	ShieldedInstanceConfig *GCPShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
+
+	// ConfidentialCompute reports whether confidential compute is effectively enabled on the GCP instance.
+	// +optional
+	ConfidentialCompute *ConfidentialComputePolicy `json:"confidentialCompute,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -209,6 +422,10 @@ type GCPMachineStatus struct {
 	// controller's output.
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the GCPMachine.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -218,6 +435,7 @@ type GCPMachineStatus struct {
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPMachine belongs"
 // +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.instanceState",description="GCE instance state"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine ready status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the readiness of the machine"
 // +kubebuilder:printcolumn:name="InstanceID",type="string",JSONPath=".spec.providerID",description="GCE instance ID"
 // +kubebuilder:printcolumn:name="Machine",type="string",JSONPath=".metadata.ownerReferences[?(@.kind==\"Machine\")].name",description="Machine object which owns with this GCPMachine"
 
@@ -230,6 +448,16 @@ type GCPMachine struct {
 	Status GCPMachineStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the observations of the operational state of the GCPMachine resource.
+func (r *GCPMachine) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPMachine to the predescribed clusterv1.Conditions.
+func (r *GCPMachine) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // GCPMachineList contains a list of GCPMachine.
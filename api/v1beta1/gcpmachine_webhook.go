@@ -17,11 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -46,6 +50,10 @@ var _ webhook.Validator = &GCPMachine{}
 func (m *GCPMachine) ValidateCreate() error {
 	clusterlog.Info("validate create", "name", m.Name)
 
+	if allErrs := validateGCPMachineSpec(m.Spec, field.NewPath("spec")); len(allErrs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, allErrs)
+	}
+
 	return nil
 }
 
@@ -99,3 +107,134 @@ func (m *GCPMachine) ValidateDelete() error {
 func (m *GCPMachine) Default() {
 	clusterlog.Info("default", "name", m.Name)
 }
+
+const (
+	// minMaxRunDuration and maxMaxRunDuration are the bounds of the run duration GCE accepts for Spot instances.
+	minMaxRunDuration = 30 * time.Second
+	maxMaxRunDuration = 120 * 24 * time.Hour
+)
+
+// confidentialComputeMachineSeries is the set of machine series supporting confidential compute.
+var confidentialComputeMachineSeries = sets.NewString("n2d", "c2d")
+
+// validateGCPMachineSpec validates the combinations of settings of a GCPMachineSpec. The features of the image,
+// e.g. UEFI or confidential computing support, are only known to GCE and are not validated.
+func validateGCPMachineSpec(spec GCPMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	isSpot := spec.ProvisioningModel != nil && *spec.ProvisioningModel == ProvisioningModelSpot
+	if isSpot && spec.Preemptible {
+		allErrs = append(allErrs,
+			field.Invalid(fldPath.Child("provisioningModel"), *spec.ProvisioningModel, "can not be combined with preemptible"),
+		)
+	}
+
+	if !isSpot && spec.InstanceTerminationAction != nil {
+		allErrs = append(allErrs,
+			field.Invalid(fldPath.Child("instanceTerminationAction"), *spec.InstanceTerminationAction, "is only allowed with the Spot provisioning model"),
+		)
+	}
+
+	if d := spec.MaxRunDuration; d != nil {
+		switch {
+		case !isSpot:
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("maxRunDuration"), d.Duration.String(), "is only allowed with the Spot provisioning model"),
+			)
+		case d.Duration < minMaxRunDuration || d.Duration > maxMaxRunDuration:
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("maxRunDuration"), d.Duration.String(),
+					fmt.Sprintf("must be between %v and %v", minMaxRunDuration, maxMaxRunDuration)),
+			)
+		}
+	}
+
+	acceleratorTypes := map[string]bool{}
+	for i, accelerator := range spec.GuestAccelerators {
+		if accelerator.Type == "" {
+			allErrs = append(allErrs,
+				field.Required(fldPath.Child("guestAccelerators").Index(i).Child("type"), "accelerator type must be set"),
+			)
+		} else if acceleratorTypes[accelerator.Type] {
+			allErrs = append(allErrs,
+				field.Duplicate(fldPath.Child("guestAccelerators").Index(i).Child("type"), accelerator.Type),
+			)
+		}
+		acceleratorTypes[accelerator.Type] = true
+		if accelerator.Count < 1 {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("guestAccelerators").Index(i).Child("count"), accelerator.Count, "must be greater than 0"),
+			)
+		}
+	}
+
+	if spec.ConfidentialCompute != nil && *spec.ConfidentialCompute == ConfidentialComputePolicyEnabled {
+		series := strings.Split(spec.InstanceType, "-")[0]
+		if !confidentialComputeMachineSeries.Has(series) {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("instanceType"), spec.InstanceType,
+					fmt.Sprintf("confidential compute requires a machine series in %v", confidentialComputeMachineSeries.List())),
+			)
+		}
+		if len(spec.GuestAccelerators) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("confidentialCompute"), *spec.ConfidentialCompute, "can not be combined with guestAccelerators"),
+			)
+		}
+	}
+
+	if config := spec.ShieldedInstanceConfig; config != nil {
+		if config.VirtualizedTrustedPlatformModule == VirtualizedTrustedPlatformModulePolicyDisabled &&
+			config.IntegrityMonitoring != IntegrityMonitoringPolicyDisabled {
+			allErrs = append(allErrs,
+				field.Invalid(fldPath.Child("shieldedInstanceConfig", "integrityMonitoring"), config.IntegrityMonitoring,
+					"integrity monitoring requires virtualizedTrustedPlatformModule to be enabled"),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, validateCustomerEncryptionKey(spec.RootDiskEncryptionKey, fldPath.Child("rootDiskEncryptionKey"))...)
+	for i, disk := range spec.AdditionalDisks {
+		diskPath := fldPath.Child("additionalDisks").Index(i)
+		if disk.EncryptionKey != nil && disk.DeviceType != nil && *disk.DeviceType == LocalSsdDiskType {
+			allErrs = append(allErrs,
+				field.Forbidden(diskPath.Child("encryptionKey"), "local-ssd disks can not be encrypted with a customer encryption key"),
+			)
+		}
+		allErrs = append(allErrs, validateCustomerEncryptionKey(disk.EncryptionKey, diskPath.Child("encryptionKey"))...)
+	}
+
+	return allErrs
+}
+
+// validateCustomerEncryptionKey validates that the key reference matches its key type.
+func validateCustomerEncryptionKey(key *CustomerEncryptionKey, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if key == nil {
+		return allErrs
+	}
+
+	switch key.KeyType {
+	case CustomerManagedKey:
+		if key.ManagedKey == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("managedKey"), "managedKey is required for the Managed key type"))
+		}
+		if key.SuppliedKey != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("suppliedKey"), "suppliedKey is not allowed for the Managed key type"))
+		}
+	case CustomerSuppliedKey:
+		if key.SuppliedKey == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("suppliedKey"), "suppliedKey is required for the Supplied key type"))
+		} else if (key.SuppliedKey.RawKey == nil) == (key.SuppliedKey.RSAEncryptedKey == nil) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("suppliedKey"), key.SuppliedKey, "exactly one of rawKey or rsaEncryptedKey must be set"))
+		}
+		if key.ManagedKey != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("managedKey"), "managedKey is not allowed for the Supplied key type"))
+		}
+		if key.KMSKeyServiceAccount != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("kmsKeyServiceAccount"), "kmsKeyServiceAccount is not allowed for the Supplied key type"))
+		}
+	}
+
+	return allErrs
+}
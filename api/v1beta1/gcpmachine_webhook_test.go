@@ -0,0 +1,298 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPMachine_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	spot := ProvisioningModelSpot
+	standard := ProvisioningModelStandard
+	terminationActionDelete := InstanceTerminationActionDelete
+	confidentialComputeEnabled := ConfidentialComputePolicyEnabled
+	localSsd := LocalSsdDiskType
+	rawKey := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-disk-key"}, Key: "key"}
+
+	tests := []struct {
+		name       string
+		GCPMachine *GCPMachine
+		wantErr    bool
+	}{
+		{
+			name: "GCPMachine with Spot provisioning model",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel:         &spot,
+					InstanceTerminationAction: &terminationActionDelete,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Spot provisioning model and preemptible",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: &spot,
+					Preemptible:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with instance termination action but Standard provisioning model",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel:         &standard,
+					InstanceTerminationAction: &terminationActionDelete,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with max run duration and Spot provisioning model",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: &spot,
+					MaxRunDuration:    &metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with max run duration but Standard provisioning model",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: &standard,
+					MaxRunDuration:    &metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with max run duration shorter than 30 seconds",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ProvisioningModel: &spot,
+					MaxRunDuration:    &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with guest accelerators",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with guest accelerator without type",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					GuestAccelerators: []Accelerator{{Count: 1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with guest accelerator with zero count",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with duplicated guest accelerator types",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 1}, {Type: "nvidia-tesla-t4", Count: 2}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with confidential compute on a supported machine series",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "n2d-standard-4",
+					ConfidentialCompute: &confidentialComputeEnabled,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with confidential compute and a custom image (only the machine series is validated)",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "n2d-standard-4",
+					Image:               pointer.String("projects/my-proj/global/images/my-image"),
+					ConfidentialCompute: &confidentialComputeEnabled,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with secure boot and a custom image family (the image is not validated)",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ImageFamily: pointer.String("projects/my-proj/global/images/family/my-family"),
+					ShieldedInstanceConfig: &GCPShieldedInstanceConfig{
+						SecureBoot: SecureBootPolicyEnabled,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with confidential compute on an unsupported machine series",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "e2-standard-4",
+					ConfidentialCompute: &confidentialComputeEnabled,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with confidential compute and guest accelerators",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:        "n2d-standard-4",
+					ConfidentialCompute: &confidentialComputeEnabled,
+					GuestAccelerators:   []Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with shielded instance config",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ShieldedInstanceConfig: &GCPShieldedInstanceConfig{
+						SecureBoot:                       SecureBootPolicyEnabled,
+						VirtualizedTrustedPlatformModule: VirtualizedTrustedPlatformModulePolicyEnabled,
+						IntegrityMonitoring:              IntegrityMonitoringPolicyEnabled,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with integrity monitoring but without vTPM",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					ShieldedInstanceConfig: &GCPShieldedInstanceConfig{
+						VirtualizedTrustedPlatformModule: VirtualizedTrustedPlatformModulePolicyDisabled,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with customer-managed root disk encryption key",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					RootDiskEncryptionKey: &CustomerEncryptionKey{
+						KeyType:              CustomerManagedKey,
+						KMSKeyServiceAccount: pointer.String("service-123@compute-system.iam.gserviceaccount.com"),
+						ManagedKey:           &ManagedKey{KMSKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Managed root disk encryption key without managedKey",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					RootDiskEncryptionKey: &CustomerEncryptionKey{
+						KeyType:     CustomerManagedKey,
+						SuppliedKey: &SuppliedKey{RawKey: rawKey},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with customer-supplied additional disk encryption key",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					AdditionalDisks: []AttachedDiskSpec{{
+						EncryptionKey: &CustomerEncryptionKey{
+							KeyType:     CustomerSuppliedKey,
+							SuppliedKey: &SuppliedKey{RawKey: rawKey},
+						},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with Supplied encryption key with both raw and RSA encrypted keys",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					AdditionalDisks: []AttachedDiskSpec{{
+						EncryptionKey: &CustomerEncryptionKey{
+							KeyType:     CustomerSuppliedKey,
+							SuppliedKey: &SuppliedKey{RawKey: rawKey, RSAEncryptedKey: rawKey},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with encryption key on a local-ssd disk",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					AdditionalDisks: []AttachedDiskSpec{{
+						DeviceType: &localSsd,
+						EncryptionKey: &CustomerEncryptionKey{
+							KeyType:     CustomerSuppliedKey,
+							SuppliedKey: &SuppliedKey{RawKey: rawKey},
+						},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.GCPMachine.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
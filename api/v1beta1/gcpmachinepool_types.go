@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/errors"
+)
+
+const (
+	// MachinePoolFinalizer allows ReconcileGCPMachinePool to clean up GCP resources associated with GCPMachinePool before
+	// removing it from the apiserver.
+	MachinePoolFinalizer = "gcpmachinepool.infrastructure.cluster.x-k8s.io"
+)
+
+// GCPMachinePoolSpec defines the desired state of GCPMachinePool.
+type GCPMachinePoolSpec struct {
+	// ProviderIDList are the identification IDs of machine instances provided by the provider.
+	// This field must match the provider IDs as seen on the node objects corresponding to a machine pool's machine instances.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+
+	// Template is the specification of the instances of the pool. It is turned into the instance template
+	// of the regional managed instance group backing the pool, changing it rolls the instances of the pool.
+	// The providerID of the template is ignored. Customer-supplied disk encryption keys are not supported by
+	// instance templates.
+	Template GCPMachineSpec `json:"template"`
+}
+
+// GCPMachinePoolStatus defines the observed state of GCPMachinePool.
+type GCPMachinePoolStatus struct {
+	// Ready is true when the provider resource is ready.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Replicas is the most recently observed number of running instances of the managed instance group.
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// InstanceTemplate is the name of the instance template currently used by the managed instance group.
+	// +optional
+	InstanceTemplate string `json:"instanceTemplate,omitempty"`
+
+	// FailureReason will be set in the event that there is a terminal problem
+	// reconciling the MachinePool and will contain a succinct value suitable
+	// for machine interpretation.
+	// +optional
+	FailureReason *errors.MachineStatusError `json:"failureReason,omitempty"`
+
+	// FailureMessage will be set in the event that there is a terminal problem
+	// reconciling the MachinePool and will contain a more verbose string suitable
+	// for logging and human consumption.
+	// +optional
+	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// Conditions defines current service state of the GCPMachinePool.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmachinepools,scope=Namespaced,categories=cluster-api,shortName=gcpmp
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPMachinePool belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine pool ready status"
+// +kubebuilder:printcolumn:name="Replicas",type="string",JSONPath=".status.replicas",description="Machine pool replicas count"
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".status.instanceTemplate",description="GCE instance template"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the readiness of the machine pool"
+
+// GCPMachinePool is the Schema for the gcpmachinepools API.
+type GCPMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPMachinePoolSpec   `json:"spec,omitempty"`
+	Status GCPMachinePoolStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the GCPMachinePool resource.
+func (r *GCPMachinePool) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPMachinePool to the predescribed clusterv1.Conditions.
+func (r *GCPMachinePool) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// GCPMachinePoolList contains a list of GCPMachinePool.
+type GCPMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPMachinePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPMachinePool{}, &GCPMachinePoolList{})
+}
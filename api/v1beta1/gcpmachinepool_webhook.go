@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var gcpmachinepoollog = logf.Log.WithName("gcpmachinepool-resource")
+
+func (r *GCPMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools,versions=v1beta1,name=validation.gcpmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &GCPMachinePool{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPMachinePool) ValidateCreate() error {
+	gcpmachinepoollog.Info("validate create", "name", r.Name)
+
+	return r.validateSpec()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The template of the pool can be changed, the instances of the pool are rolled to the new template.
+func (r *GCPMachinePool) ValidateUpdate(_ runtime.Object) error {
+	gcpmachinepoollog.Info("validate update", "name", r.Name)
+
+	return r.validateSpec()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPMachinePool) ValidateDelete() error {
+	gcpmachinepoollog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *GCPMachinePool) validateSpec() error {
+	fldPath := field.NewPath("spec", "template")
+	allErrs := validateGCPMachineSpec(r.Spec.Template, fldPath)
+	allErrs = append(allErrs, validateInstanceTemplateSpec(r.Spec.Template, fldPath)...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// validateInstanceTemplateSpec rejects the settings of a GCPMachineSpec GCE only accepts for single instances. The
+// instance templates of managed instance groups can't hold customer-supplied encryption keys.
+func validateInstanceTemplateSpec(spec GCPMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if key := spec.RootDiskEncryptionKey; key != nil && key.KeyType == CustomerSuppliedKey {
+		allErrs = append(allErrs,
+			field.Forbidden(fldPath.Child("rootDiskEncryptionKey", "keyType"), "customer-supplied keys are not supported by instance templates"),
+		)
+	}
+	for i, disk := range spec.AdditionalDisks {
+		if key := disk.EncryptionKey; key != nil && key.KeyType == CustomerSuppliedKey {
+			allErrs = append(allErrs,
+				field.Forbidden(fldPath.Child("additionalDisks").Index(i).Child("encryptionKey", "keyType"), "customer-supplied keys are not supported by instance templates"),
+			)
+		}
+	}
+
+	return allErrs
+}
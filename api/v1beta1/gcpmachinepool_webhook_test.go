@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGCPMachinePool_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	rawKey := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-disk-key"}, Key: "key"}
+
+	tests := []struct {
+		name           string
+		GCPMachinePool *GCPMachinePool
+		wantErr        bool
+	}{
+		{
+			name: "GCPMachinePool with customer-managed root disk encryption key",
+			GCPMachinePool: &GCPMachinePool{
+				Spec: GCPMachinePoolSpec{
+					Template: GCPMachineSpec{
+						RootDiskEncryptionKey: &CustomerEncryptionKey{
+							KeyType:    CustomerManagedKey,
+							ManagedKey: &ManagedKey{KMSKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachinePool with customer-supplied root disk encryption key",
+			GCPMachinePool: &GCPMachinePool{
+				Spec: GCPMachinePoolSpec{
+					Template: GCPMachineSpec{
+						RootDiskEncryptionKey: &CustomerEncryptionKey{
+							KeyType:     CustomerSuppliedKey,
+							SuppliedKey: &SuppliedKey{RawKey: rawKey},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachinePool with customer-supplied additional disk encryption key",
+			GCPMachinePool: &GCPMachinePool{
+				Spec: GCPMachinePoolSpec{
+					Template: GCPMachineSpec{
+						AdditionalDisks: []AttachedDiskSpec{{
+							EncryptionKey: &CustomerEncryptionKey{
+								KeyType:     CustomerSuppliedKey,
+								SuppliedKey: &SuppliedKey{RSAEncryptedKey: rawKey},
+							},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.GCPMachinePool.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
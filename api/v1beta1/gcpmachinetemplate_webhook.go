@@ -46,6 +46,10 @@ var _ webhook.Validator = &GCPMachineTemplate{}
 func (r *GCPMachineTemplate) ValidateCreate() error {
 	clusterlog.Info("validate create", "name", r.Name)
 
+	if allErrs := validateGCPMachineSpec(r.Spec.Template.Spec, field.NewPath("spec", "template", "spec")); len(allErrs) > 0 {
+		return apierrors.NewInvalid(GroupVersion.WithKind("GCPMachineTemplate").GroupKind(), r.Name, allErrs)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ManagedClusterFinalizer allows ReconcileGCPManagedCluster to clean up GCP resources associated with GCPManagedCluster before
+	// removing it from the apiserver.
+	ManagedClusterFinalizer = "gcpmanagedcluster.infrastructure.cluster.x-k8s.io"
+)
+
+// GCPManagedClusterSpec defines the desired state of GCPManagedCluster.
+type GCPManagedClusterSpec struct {
+	// Project is the name of the project to deploy the GKE cluster to.
+	Project string `json:"project"`
+
+	// The GCP Region the GKE cluster lives in.
+	Region string `json:"region"`
+
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// It is copied from the GCPManagedControlPlane once the GKE cluster is running.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+
+	// NetworkSpec encapsulates all things related to the GCP network the GKE cluster is attached to.
+	// +optional
+	Network NetworkSpec `json:"network"`
+
+	// AdditionalLabels is an optional set of tags to add to GCP resources managed by the GCP provider, in addition to the
+	// ones added by default.
+	// +optional
+	AdditionalLabels Labels `json:"additionalLabels,omitempty"`
+
+	// IdentityRef is a reference to the GCPClusterIdentity holding the credentials
+	// used to manage the cluster resources. When not set, the controller credentials are used.
+	// +optional
+	IdentityRef *GCPIdentityReference `json:"identityRef,omitempty"`
+}
+
+// GCPManagedClusterStatus defines the observed state of GCPManagedCluster.
+type GCPManagedClusterStatus struct {
+	Network Network `json:"network,omitempty"`
+
+	// Ready is true when the network of the GKE cluster is ready.
+	Ready bool `json:"ready"`
+
+	// Conditions defines current service state of the GCPManagedCluster.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmanagedclusters,scope=Namespaced,categories=cluster-api,shortName=gcpmc
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPManagedCluster belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Cluster infrastructure is ready for the GKE cluster"
+// +kubebuilder:printcolumn:name="Network",type="string",JSONPath=".spec.network.name",description="GCP network the cluster is using"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint.host",description="API Endpoint",priority=1
+
+// GCPManagedCluster is the Schema for the gcpmanagedclusters API.
+type GCPManagedCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPManagedClusterSpec   `json:"spec,omitempty"`
+	Status GCPManagedClusterStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the GCPManagedCluster resource.
+func (r *GCPManagedCluster) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPManagedCluster to the predescribed clusterv1.Conditions.
+func (r *GCPManagedCluster) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// GCPManagedClusterList contains a list of GCPManagedCluster.
+type GCPManagedClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPManagedCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPManagedCluster{}, &GCPManagedClusterList{})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ManagedControlPlaneFinalizer allows ReconcileGCPManagedControlPlane to clean up the GKE cluster associated with
+	// GCPManagedControlPlane before removing it from the apiserver.
+	ManagedControlPlaneFinalizer = "gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io"
+)
+
+// ReleaseChannel is the release channel a GKE cluster is subscribed to.
+// +kubebuilder:validation:Enum=rapid;regular;stable
+type ReleaseChannel string
+
+const (
+	// Rapid release channel.
+	Rapid ReleaseChannel = "rapid"
+	// Regular release channel.
+	Regular ReleaseChannel = "regular"
+	// Stable release channel.
+	Stable ReleaseChannel = "stable"
+)
+
+// GCPManagedControlPlaneSpec defines the desired state of GCPManagedControlPlane.
+type GCPManagedControlPlaneSpec struct {
+	// ClusterName is the name of the GKE cluster. It defaults to the name of the Cluster.
+	// +kubebuilder:validation:MaxLength:=40
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// EnableAutopilot indicates whether to create an Autopilot cluster, GKE then manages the nodes of the cluster.
+	// Standard clusters are created once their first GCPManagedMachinePool exists, with the node pools of the cluster.
+	// +optional
+	EnableAutopilot bool `json:"enableAutopilot,omitempty"`
+
+	// ReleaseChannel is the release channel the GKE cluster is subscribed to.
+	// +optional
+	ReleaseChannel *ReleaseChannel `json:"releaseChannel,omitempty"`
+
+	// ControlPlaneVersion is the Kubernetes version of the control plane, e.g. "1.24" or "1.24.5-gke.600".
+	// When not set, GKE uses the default version of the release channel. Changing it upgrades the control plane.
+	// +optional
+	ControlPlaneVersion *string `json:"controlPlaneVersion,omitempty"`
+
+	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
+	// It is set by the controller once the GKE cluster is running.
+	// +optional
+	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+}
+
+// GCPManagedControlPlaneStatus defines the observed state of GCPManagedControlPlane.
+type GCPManagedControlPlaneStatus struct {
+	// Ready denotes that the GKE cluster is running and its API server can receive requests.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Initialized is true when the GKE cluster has been created and its kubeconfig secret is available.
+	// +optional
+	Initialized bool `json:"initialized,omitempty"`
+
+	// CurrentVersion is the Kubernetes version of the control plane reported by GKE.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// Conditions defines current service state of the GCPManagedControlPlane.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmanagedcontrolplanes,scope=Namespaced,categories=cluster-api,shortName=gcpmcp
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPManagedControlPlane belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Control plane is ready"
+// +kubebuilder:printcolumn:name="CurrentVersion",type="string",JSONPath=".status.currentVersion",description="The current Kubernetes version"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.controlPlaneEndpoint.host",description="API Endpoint",priority=1
+
+// GCPManagedControlPlane is the Schema for the gcpmanagedcontrolplanes API.
+type GCPManagedControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPManagedControlPlaneSpec   `json:"spec,omitempty"`
+	Status GCPManagedControlPlaneStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the GCPManagedControlPlane resource.
+func (r *GCPManagedControlPlane) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPManagedControlPlane to the predescribed clusterv1.Conditions.
+func (r *GCPManagedControlPlane) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// GCPManagedControlPlaneList contains a list of GCPManagedControlPlane.
+type GCPManagedControlPlaneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPManagedControlPlane `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPManagedControlPlane{}, &GCPManagedControlPlaneList{})
+}
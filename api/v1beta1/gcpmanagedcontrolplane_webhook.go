@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var gcpmanagedcontrolplanelog = logf.Log.WithName("gcpmanagedcontrolplane-resource")
+
+// gkeClusterNameRegexp matches the names allowed for GKE clusters.
+var gkeClusterNameRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+func (r *GCPManagedControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedcontrolplane,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,versions=v1beta1,name=validation.gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &GCPManagedControlPlane{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedControlPlane) ValidateCreate() error {
+	gcpmanagedcontrolplanelog.Info("validate create", "name", r.Name)
+	var allErrs field.ErrorList
+
+	if r.Spec.ClusterName != "" && !gkeClusterNameRegexp.MatchString(r.Spec.ClusterName) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "clusterName"),
+				r.Spec.ClusterName, "must start with a lowercase letter followed by lowercase letters, numbers or hyphens, and must not end with a hyphen"),
+		)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The release channel and the control plane version can be changed, the GKE cluster is updated in place.
+func (r *GCPManagedControlPlane) ValidateUpdate(oldRaw runtime.Object) error {
+	gcpmanagedcontrolplanelog.Info("validate update", "name", r.Name)
+	var allErrs field.ErrorList
+	old := oldRaw.(*GCPManagedControlPlane)
+
+	if !reflect.DeepEqual(r.Spec.ClusterName, old.Spec.ClusterName) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "clusterName"),
+				r.Spec.ClusterName, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(r.Spec.EnableAutopilot, old.Spec.EnableAutopilot) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "enableAutopilot"),
+				r.Spec.EnableAutopilot, "field is immutable"),
+		)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlane").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedControlPlane) ValidateDelete() error {
+	gcpmanagedcontrolplanelog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGCPManagedControlPlane_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name         string
+		controlPlane *GCPManagedControlPlane
+		wantErr      bool
+	}{
+		{
+			name: "GCPManagedControlPlane without cluster name",
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedControlPlane with cluster name",
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					ClusterName: "my-gke-cluster",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedControlPlane with invalid cluster name",
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					ClusterName: "My_GKE_Cluster",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.controlPlane.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGCPManagedControlPlane_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	regular := Regular
+
+	tests := []struct {
+		name            string
+		oldControlPlane *GCPManagedControlPlane
+		controlPlane    *GCPManagedControlPlane
+		wantErr         bool
+	}{
+		{
+			name: "GCPManagedControlPlane with updated release channel",
+			oldControlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{},
+			},
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					ReleaseChannel: &regular,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedControlPlane with updated cluster name",
+			oldControlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					ClusterName: "my-gke-cluster",
+				},
+			},
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					ClusterName: "my-other-gke-cluster",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedControlPlane with updated autopilot",
+			oldControlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{},
+			},
+			controlPlane: &GCPManagedControlPlane{
+				Spec: GCPManagedControlPlaneSpec{
+					EnableAutopilot: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.controlPlane.ValidateUpdate(test.oldControlPlane)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ManagedMachinePoolFinalizer allows ReconcileGCPManagedMachinePool to clean up the GKE node pool associated with
+	// GCPManagedMachinePool before removing it from the apiserver.
+	ManagedMachinePoolFinalizer = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io"
+)
+
+// TaintEffect is the effect of a taint of the nodes of a node pool.
+// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+type TaintEffect string
+
+const (
+	// TaintEffectNoSchedule does not schedule new pods on the node.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+	// TaintEffectPreferNoSchedule avoids scheduling new pods on the node.
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	// TaintEffectNoExecute evicts the running pods from the node.
+	TaintEffectNoExecute TaintEffect = "NoExecute"
+)
+
+// Taint is a Kubernetes taint of the nodes of a node pool.
+type Taint struct {
+	// Effect specifies the effect of the taint.
+	Effect TaintEffect `json:"effect"`
+
+	// Key is the key of the taint.
+	Key string `json:"key"`
+
+	// Value is the value of the taint.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// Taints is a list of taints.
+type Taints []Taint
+
+// NodePoolAutoScaling specifies the bounds of the cluster autoscaler for a node pool.
+type NodePoolAutoScaling struct {
+	// MinCount is the minimum number of nodes per zone of the node pool.
+	// +kubebuilder:validation:Minimum=0
+	MinCount int32 `json:"minCount"`
+
+	// MaxCount is the maximum number of nodes per zone of the node pool.
+	// +kubebuilder:validation:Minimum=1
+	MaxCount int32 `json:"maxCount"`
+}
+
+// NodePoolUpgradeSettings specifies the surge upgrade settings of a node pool.
+type NodePoolUpgradeSettings struct {
+	// MaxSurge is the maximum number of nodes created beyond the size of the node pool during an upgrade.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSurge *int32 `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of nodes simultaneously unavailable during an upgrade.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
+// GCPManagedMachinePoolSpec defines the desired state of GCPManagedMachinePool.
+type GCPManagedMachinePoolSpec struct {
+	// NodePoolName is the name of the GKE node pool. It defaults to the name of the GCPManagedMachinePool.
+	// +kubebuilder:validation:MaxLength:=40
+	// +optional
+	NodePoolName string `json:"nodePoolName,omitempty"`
+
+	// MachineType is the GCE machine type of the nodes, e.g. "e2-medium".
+	// When not set, GKE picks its default machine type.
+	// +optional
+	MachineType *string `json:"machineType,omitempty"`
+
+	// DiskSizeGB is the size of the boot disk of the nodes, in GB. When not set, GKE picks its default size.
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	DiskSizeGB *int64 `json:"diskSizeGB,omitempty"`
+
+	// DiskType is the type of the boot disk of the nodes. When not set, GKE picks its default disk type.
+	// +kubebuilder:validation:Enum=pd-standard;pd-ssd;pd-balanced
+	// +optional
+	DiskType *DiskType `json:"diskType,omitempty"`
+
+	// Scaling enables the cluster autoscaler on the node pool within the given bounds. The replicas of the
+	// MachinePool are ignored once the node pool is autoscaled.
+	// +optional
+	Scaling *NodePoolAutoScaling `json:"scaling,omitempty"`
+
+	// KubernetesLabels are the Kubernetes labels applied to the nodes of the node pool.
+	// +optional
+	KubernetesLabels map[string]string `json:"kubernetesLabels,omitempty"`
+
+	// KubernetesTaints are the Kubernetes taints applied to the nodes of the node pool.
+	// +optional
+	KubernetesTaints Taints `json:"kubernetesTaints,omitempty"`
+
+	// Spot indicates whether the nodes of the node pool are Spot VMs.
+	// +optional
+	Spot bool `json:"spot,omitempty"`
+
+	// UpgradeSettings are the surge upgrade settings of the node pool. When not set, GKE upgrades
+	// a single node at a time.
+	// +optional
+	UpgradeSettings *NodePoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+
+	// ProviderIDList are the provider IDs of the instances of the node pool.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+}
+
+// GCPManagedMachinePoolStatus defines the observed state of GCPManagedMachinePool.
+type GCPManagedMachinePoolStatus struct {
+	// Ready is true when the node pool has been created and its nodes can be registered with the MachinePool.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// Replicas is the most recently observed number of running nodes of the node pool.
+	// +optional
+	Replicas int32 `json:"replicas"`
+
+	// Conditions defines current service state of the GCPManagedMachinePool.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmanagedmachinepools,scope=Namespaced,categories=cluster-api,shortName=gcpmmp
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels.cluster\\.x-k8s\\.io/cluster-name",description="Cluster to which this GCPManagedMachinePool belongs"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Node pool ready status"
+// +kubebuilder:printcolumn:name="Replicas",type="string",JSONPath=".status.replicas",description="Node pool replicas count"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].reason",description="Reason for the readiness of the node pool"
+
+// GCPManagedMachinePool is the Schema for the gcpmanagedmachinepools API.
+type GCPManagedMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPManagedMachinePoolSpec   `json:"spec,omitempty"`
+	Status GCPManagedMachinePoolStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the observations of the operational state of the GCPManagedMachinePool resource.
+func (r *GCPManagedMachinePool) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the underlying service state of the GCPManagedMachinePool to the predescribed clusterv1.Conditions.
+func (r *GCPManagedMachinePool) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// GCPManagedMachinePoolList contains a list of GCPManagedMachinePool.
+type GCPManagedMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPManagedMachinePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPManagedMachinePool{}, &GCPManagedMachinePoolList{})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var gcpmanagedmachinepoollog = logf.Log.WithName("gcpmanagedmachinepool-resource")
+
+func (r *GCPManagedMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedmachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,versions=v1beta1,name=validation.gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &GCPManagedMachinePool{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedMachinePool) ValidateCreate() error {
+	gcpmanagedmachinepoollog.Info("validate create", "name", r.Name)
+	var allErrs field.ErrorList
+
+	if r.Spec.NodePoolName != "" && !gkeClusterNameRegexp.MatchString(r.Spec.NodePoolName) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "nodePoolName"),
+				r.Spec.NodePoolName, "must start with a lowercase letter followed by lowercase letters, numbers or hyphens, and must not end with a hyphen"),
+		)
+	}
+
+	allErrs = append(allErrs, r.validateScaling()...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The node configuration can't be changed once the node pool is created, the autoscaling bounds, the
+// Kubernetes labels and taints and the upgrade settings are updated in place.
+func (r *GCPManagedMachinePool) ValidateUpdate(oldRaw runtime.Object) error {
+	gcpmanagedmachinepoollog.Info("validate update", "name", r.Name)
+	var allErrs field.ErrorList
+	old := oldRaw.(*GCPManagedMachinePool)
+
+	if !reflect.DeepEqual(r.Spec.NodePoolName, old.Spec.NodePoolName) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "nodePoolName"),
+				r.Spec.NodePoolName, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(r.Spec.MachineType, old.Spec.MachineType) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "machineType"),
+				r.Spec.MachineType, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(r.Spec.DiskSizeGB, old.Spec.DiskSizeGB) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "diskSizeGB"),
+				r.Spec.DiskSizeGB, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(r.Spec.DiskType, old.Spec.DiskType) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "diskType"),
+				r.Spec.DiskType, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(r.Spec.Spot, old.Spec.Spot) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "spot"),
+				r.Spec.Spot, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, r.validateScaling()...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedMachinePool) ValidateDelete() error {
+	gcpmanagedmachinepoollog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if scaling := r.Spec.Scaling; scaling != nil && scaling.MinCount > scaling.MaxCount {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "scaling", "minCount"),
+				scaling.MinCount, "must be less than or equal to maxCount"),
+		)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPManagedMachinePool_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name        string
+		machinePool *GCPManagedMachinePool
+		wantErr     bool
+	}{
+		{
+			name: "GCPManagedMachinePool with autoscaling",
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					NodePoolName: "my-pool",
+					Scaling:      &NodePoolAutoScaling{MinCount: 1, MaxCount: 3},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with invalid node pool name",
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					NodePoolName: "My_Pool",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with min count greater than max count",
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Scaling: &NodePoolAutoScaling{MinCount: 3, MaxCount: 1},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.machinePool.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestGCPManagedMachinePool_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name           string
+		oldMachinePool *GCPManagedMachinePool
+		machinePool    *GCPManagedMachinePool
+		wantErr        bool
+	}{
+		{
+			name: "GCPManagedMachinePool with updated labels and taints",
+			oldMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{},
+			},
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					KubernetesLabels: map[string]string{"role": "worker"},
+					KubernetesTaints: Taints{{Key: "dedicated", Value: "worker", Effect: TaintEffectNoSchedule}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with updated machine type",
+			oldMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: pointer.String("e2-medium"),
+				},
+			},
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: pointer.String("e2-standard-4"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with updated spot",
+			oldMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{},
+			},
+			machinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Spot: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			err := test.machinePool.ValidateUpdate(test.oldMachinePool)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
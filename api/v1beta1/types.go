@@ -56,6 +56,10 @@ type Network struct {
 	// +optional
 	Router *string `json:"router,omitempty"`
 
+	// Subnets is a map from the name of the subnetwork to its full reference.
+	// +optional
+	Subnets map[string]string `json:"subnets,omitempty"`
+
 	// APIServerAddress is the IPV4 global address assigned to the load balancer
 	// created for the API Server.
 	// +optional
@@ -85,6 +89,16 @@ type Network struct {
 	// created for the API Server.
 	// +optional
 	APIServerForwardingRule *string `json:"apiServerForwardingRule,omitempty"`
+
+	// APIServerSecurityPolicy is the full reference to the security policy
+	// attached to the backend service of the API Server.
+	// +optional
+	APIServerSecurityPolicy *string `json:"apiServerSecurityPolicy,omitempty"`
+
+	// AdditionalPortForwardingRules is a map from the name of the additional port
+	// of the load balancer to the full reference of its forwarding rule.
+	// +optional
+	AdditionalPortForwardingRules map[string]string `json:"additionalPortForwardingRules,omitempty"`
 }
 
 // NetworkSpec encapsulates all things related to a GCP network.
@@ -109,9 +123,273 @@ type NetworkSpec struct {
 	// +optional
 	Subnets Subnets `json:"subnets,omitempty"`
 
-	// Allow for configuration of load balancer backend (useful for changing apiserver port)
+	// Allow for configuration of load balancer backend (useful for changing apiserver port).
+	// The API server health check and its firewall rule probe the same port. Defaults to 6443. Immutable.
 	// +optional
 	LoadBalancerBackendPort *int32 `json:"loadBalancerBackendPort,omitempty"`
+
+	// HostProject is the name of the project hosting the shared VPC network resources.
+	// When set, the network, subnetworks, firewall rules and cloud nat router are looked up
+	// in this project instead of the cluster project, and the network and its subnetworks
+	// must already exist.
+	// +optional
+	HostProject *string `json:"hostProject,omitempty"`
+
+	// FirewallRules is a list of additional firewall rules created in the network for the cluster,
+	// next to the rules allowing the load balancer health checks and the traffic between the
+	// cluster machines. Only used by GCPCluster.
+	// +optional
+	FirewallRules FirewallRules `json:"firewallRules,omitempty"`
+}
+
+// FirewallRuleDirection is the direction of the traffic a firewall rule applies to.
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIngress applies the firewall rule to incoming traffic.
+	FirewallRuleDirectionIngress = FirewallRuleDirection("INGRESS")
+
+	// FirewallRuleDirectionEgress applies the firewall rule to outgoing traffic.
+	FirewallRuleDirectionEgress = FirewallRuleDirection("EGRESS")
+)
+
+// FirewallRuleAction is the action a firewall rule takes on the matching traffic.
+type FirewallRuleAction string
+
+const (
+	// FirewallRuleActionAllow allows the matching traffic.
+	FirewallRuleActionAllow = FirewallRuleAction("Allow")
+
+	// FirewallRuleActionDeny denies the matching traffic.
+	FirewallRuleActionDeny = FirewallRuleAction("Deny")
+)
+
+// FirewallRuleProtocol is a protocol, and optionally ports, matched by a firewall rule.
+type FirewallRuleProtocol struct {
+	// Protocol is the IP protocol matched by the rule, either one of tcp, udp, icmp, esp, ah,
+	// sctp, ipip and all, or an IP protocol number.
+	Protocol string `json:"protocol"`
+
+	// Ports is a list of ports, e.g. 22, or port ranges, e.g. 30000-32767, matched by the rule.
+	// Only applicable to the tcp, udp and sctp protocols, all ports are matched when empty.
+	// +optional
+	Ports []string `json:"ports,omitempty"`
+}
+
+// FirewallRule defines a firewall rule created in the network of the cluster.
+type FirewallRule struct {
+	// Name of the firewall rule, unique within the cluster. The rule is created as <cluster-name>-<name>.
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Direction of the traffic the rule applies to. Defaults to INGRESS.
+	// +kubebuilder:validation:Enum=INGRESS;EGRESS
+	// +optional
+	Direction *FirewallRuleDirection `json:"direction,omitempty"`
+
+	// Action taken on the matching traffic. Defaults to Allow.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	// +optional
+	Action *FirewallRuleAction `json:"action,omitempty"`
+
+	// Priority of the rule, from 0 (highest) to 65535 (lowest). Defaults to 1000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Protocols is the list of protocols and ports matched by the rule.
+	// +kubebuilder:validation:MinItems=1
+	Protocols []FirewallRuleProtocol `json:"protocols"`
+
+	// SourceRanges is a list of CIDR ranges the ingress traffic comes from. Defaults to 0.0.0.0/0 when
+	// no source ranges, tags or service accounts are set.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// DestinationRanges is a list of CIDR ranges the egress traffic goes to. Defaults to 0.0.0.0/0.
+	// +optional
+	DestinationRanges []string `json:"destinationRanges,omitempty"`
+
+	// SourceTags is a list of network tags of the instances the ingress traffic comes from.
+	// +optional
+	SourceTags []string `json:"sourceTags,omitempty"`
+
+	// TargetTags is a list of network tags of the instances the rule applies to.
+	// The rule applies to all the instances of the network when neither TargetTags nor
+	// TargetServiceAccounts are set.
+	// +optional
+	TargetTags []string `json:"targetTags,omitempty"`
+
+	// SourceServiceAccounts is a list of service accounts of the instances the ingress traffic comes from.
+	// Can't be combined with SourceTags or TargetTags.
+	// +optional
+	SourceServiceAccounts []string `json:"sourceServiceAccounts,omitempty"`
+
+	// TargetServiceAccounts is a list of service accounts of the instances the rule applies to.
+	// Can't be combined with SourceTags or TargetTags.
+	// +optional
+	TargetServiceAccounts []string `json:"targetServiceAccounts,omitempty"`
+}
+
+// FirewallRules is a slice of FirewallRule.
+type FirewallRules []FirewallRule
+
+// LoadBalancerType defines the type of load balancer created for the API server.
+type LoadBalancerType string
+
+const (
+	// External creates a global external TCP proxy load balancer reachable from the internet.
+	External = LoadBalancerType("External")
+
+	// Internal creates a regional internal passthrough load balancer reachable only inside the VPC.
+	Internal = LoadBalancerType("Internal")
+
+	// RegionalExternal creates a regional external passthrough load balancer reachable from the internet,
+	// which preserves the client source addresses.
+	RegionalExternal = LoadBalancerType("RegionalExternal")
+
+	// None does not create a load balancer, the control plane endpoint is provided by the user
+	// and the control plane machines are not registered into instance groups.
+	None = LoadBalancerType("None")
+)
+
+// IsRegional returns true if the load balancer type is built on regional passthrough components.
+func (t LoadBalancerType) IsRegional() bool {
+	return t == Internal || t == RegionalExternal
+}
+
+// LoadBalancerSpec contains configuration for the API server load balancer.
+type LoadBalancerSpec struct {
+	// LoadBalancerType defines the type of load balancer created for the API server.
+	// Defaults to External.
+	// +kubebuilder:validation:Enum=External;Internal;RegionalExternal;None
+	// +optional
+	LoadBalancerType *LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// Subnet is the name of the subnetwork the internal load balancer address is allocated from.
+	// Only used by the Internal load balancer type and the internal API server endpoint. If not specified, the first subnet declared
+	// in the cluster region is used, which is required for networks in "custom" mode.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+
+	// ReservedAddress is the name or the IP address of an existing static address used by the load balancer,
+	// global for the External load balancer type and regional otherwise. The address is not owned by the cluster
+	// and is never released. When not set, an address is reserved for the cluster.
+	// +optional
+	ReservedAddress *string `json:"reservedAddress,omitempty"`
+
+	// AllowedSourceRanges is the list of CIDR ranges allowed to reach the API server through the load balancer.
+	// Only supported by the Internal and RegionalExternal load balancer types, which preserve the client source
+	// addresses and are filtered by a firewall rule of the control plane nodes. Defaults to 0.0.0.0/0 for the
+	// RegionalExternal type, which exposes the API server to the whole internet. Defaults to the CIDR blocks of
+	// the subnets of the network spec for the Internal type, or to 10.128.0.0/9, the range of the subnetworks of
+	// auto mode networks, when none is declared.
+	// +kubebuilder:validation:MaxItems=256
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
+
+	// HealthCheck configures the health check probing the API server behind the load balancer.
+	// +optional
+	HealthCheck *LoadBalancerHealthCheck `json:"healthCheck,omitempty"`
+
+	// SecurityPolicy is the Cloud Armor security policy attached to the backend service of the API server.
+	// Only supported by the External load balancer type.
+	// +optional
+	SecurityPolicy *SecurityPolicySpec `json:"securityPolicy,omitempty"`
+
+	// AdditionalPorts are the ports of the control plane nodes reachable through the address of the load balancer
+	// in addition to the API server, e.g. for Konnectivity. Each port gets its own health check, backend service
+	// and forwarding rule. Only supported by the External and RegionalExternal load balancer types. The External
+	// type relies on a TCP proxy, which only accepts the ports 25, 43, 110, 143, 195, 443, 465, 587, 700, 993, 995,
+	// 1883, 3389, 5222, 5432, 5671, 5672, 5900, 5901, 6379, 8085, 8099, 9092, 9200 and 9300.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AdditionalPorts []LoadBalancerPort `json:"additionalPorts,omitempty"`
+}
+
+// LoadBalancerPort defines an additional port of the load balancer, forwarded to the same port of the control
+// plane nodes.
+type LoadBalancerPort struct {
+	// Name is the name of the port, used as the named port of the control plane instance groups and in the names
+	// of its load balancer resources. The names apiserver and apiserver-internal are reserved for the API server.
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Port is the TCP port the load balancer listens on and the control plane nodes serve.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// SecurityPolicySpec defines the Cloud Armor security policy filtering the requests to the API server.
+// Exactly one of Name or AllowedSourceRanges must be set.
+type SecurityPolicySpec struct {
+	// Name is the name of an existing security policy. The policy is not owned by the cluster
+	// and is only detached from the load balancer on deletion.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// AllowedSourceRanges is the list of CIDR ranges allowed to reach the API server, requests
+	// from any other source are denied. The controller reconciles them into a security policy
+	// owned by the cluster.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	AllowedSourceRanges []string `json:"allowedSourceRanges,omitempty"`
+}
+
+// HealthCheckProtocol defines the protocol of the API server health check.
+type HealthCheckProtocol string
+
+const (
+	// HealthCheckProtocolSSL checks the API server completes an SSL handshake.
+	HealthCheckProtocolSSL = HealthCheckProtocol("SSL")
+
+	// HealthCheckProtocolHTTPS checks the API server answers an HTTPS request with a 200 status,
+	// i.e. it is ready to serve requests.
+	HealthCheckProtocolHTTPS = HealthCheckProtocol("HTTPS")
+)
+
+// LoadBalancerHealthCheck defines the health check probing the API server behind the load balancer.
+type LoadBalancerHealthCheck struct {
+	// Protocol is the protocol of the health check. Defaults to SSL.
+	// +kubebuilder:validation:Enum=SSL;HTTPS
+	// +optional
+	Protocol *HealthCheckProtocol `json:"protocol,omitempty"`
+
+	// RequestPath is the path of the HTTPS health check requests. Defaults to /readyz.
+	// Only used by the HTTPS protocol.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	RequestPath *string `json:"requestPath,omitempty"`
+
+	// CheckIntervalSec is how often, in seconds, the API server is probed. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	CheckIntervalSec *int64 `json:"checkIntervalSec,omitempty"`
+
+	// TimeoutSec is how long, in seconds, a probe waits for the API server. It can not be greater than
+	// the check interval. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful probes marking the API server healthy. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	HealthyThreshold *int64 `json:"healthyThreshold,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed probes marking the API server unhealthy. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThreshold *int64 `json:"unhealthyThreshold,omitempty"`
 }
 
 // SubnetSpec configures an GCP Subnet.
@@ -127,6 +405,8 @@ type SubnetSpec struct {
 	CidrBlock string `json:"cidrBlock,omitempty"`
 
 	// Description is an optional description associated with the resource.
+	// The description of the subnetwork created by capg is prefixed with the
+	// tag of the cluster owning it.
 	// +optional
 	Description *string `json:"description,omitempty"`
 
@@ -23,11 +23,52 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Accelerator) DeepCopyInto(out *Accelerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Accelerator.
+func (in *Accelerator) DeepCopy() *Accelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(Accelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
+	*out = *in
+	if in.NamespaceList != nil {
+		in, out := &in.NamespaceList, &out.NamespaceList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(AllowedNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 	*out = *in
@@ -36,72 +77,1073 @@ func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 		*out = new(DiskType)
 		**out = **in
 	}
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int64)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EncryptionKey != nil {
+		in, out := &in.EncryptionKey, &out.EncryptionKey
+		*out = new(CustomerEncryptionKey)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskSpec.
+func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachedDiskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
+	*out = *in
+	if in.MachineType != nil {
+		in, out := &in.MachineType, &out.MachineType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionSpec.
+func (in *BastionSpec) DeepCopy() *BastionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BastionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BastionStatus) DeepCopyInto(out *BastionStatus) {
+	*out = *in
+	if in.SelfLink != nil {
+		in, out := &in.SelfLink, &out.SelfLink
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceStatus != nil {
+		in, out := &in.InstanceStatus, &out.InstanceStatus
+		*out = new(InstanceStatus)
+		**out = **in
+	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]v1.NodeAddress, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionStatus.
+func (in *BastionStatus) DeepCopy() *BastionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BastionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildParams) DeepCopyInto(out *BuildParams) {
+	*out = *in
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildParams.
+func (in *BuildParams) DeepCopy() *BuildParams {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerEncryptionKey) DeepCopyInto(out *CustomerEncryptionKey) {
+	*out = *in
+	if in.KMSKeyServiceAccount != nil {
+		in, out := &in.KMSKeyServiceAccount, &out.KMSKeyServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.ManagedKey != nil {
+		in, out := &in.ManagedKey, &out.ManagedKey
+		*out = new(ManagedKey)
+		**out = **in
+	}
+	if in.SuppliedKey != nil {
+		in, out := &in.SuppliedKey, &out.SuppliedKey
+		*out = new(SuppliedKey)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerEncryptionKey.
+func (in *CustomerEncryptionKey) DeepCopy() *CustomerEncryptionKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerEncryptionKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Filter) DeepCopyInto(out *Filter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
+func (in *Filter) DeepCopy() *Filter {
+	if in == nil {
+		return nil
+	}
+	out := new(Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Direction != nil {
+		in, out := &in.Direction, &out.Direction
+		*out = new(FirewallRuleDirection)
+		**out = **in
+	}
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(FirewallRuleAction)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Protocols != nil {
+		in, out := &in.Protocols, &out.Protocols
+		*out = make([]FirewallRuleProtocol, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationRanges != nil {
+		in, out := &in.DestinationRanges, &out.DestinationRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceTags != nil {
+		in, out := &in.SourceTags, &out.SourceTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetTags != nil {
+		in, out := &in.TargetTags, &out.TargetTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceServiceAccounts != nil {
+		in, out := &in.SourceServiceAccounts, &out.SourceServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetServiceAccounts != nil {
+		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRuleProtocol) DeepCopyInto(out *FirewallRuleProtocol) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRuleProtocol.
+func (in *FirewallRuleProtocol) DeepCopy() *FirewallRuleProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRuleProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in FirewallRules) DeepCopyInto(out *FirewallRules) {
+	{
+		in := &in
+		*out = make(FirewallRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRules.
+func (in FirewallRules) DeepCopy() FirewallRules {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRules)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCluster.
+func (in *GCPCluster) DeepCopy() *GCPCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterIdentity) DeepCopyInto(out *GCPClusterIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentity.
+func (in *GCPClusterIdentity) DeepCopy() *GCPClusterIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterIdentityList) DeepCopyInto(out *GCPClusterIdentityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPClusterIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentityList.
+func (in *GCPClusterIdentityList) DeepCopy() *GCPClusterIdentityList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterIdentityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterIdentitySpec) DeepCopyInto(out *GCPClusterIdentitySpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.ImpersonateServiceAccount != nil {
+		in, out := &in.ImpersonateServiceAccount, &out.ImpersonateServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentitySpec.
+func (in *GCPClusterIdentitySpec) DeepCopy() *GCPClusterIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterList) DeepCopyInto(out *GCPClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterList.
+func (in *GCPClusterList) DeepCopy() *GCPClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
+	*out = *in
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	in.Network.DeepCopyInto(&out.Network)
+	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(GCPIdentityReference)
+		**out = **in
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(BastionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IAP != nil {
+		in, out := &in.IAP, &out.IAP
+		*out = new(IAPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
+func (in *GCPClusterSpec) DeepCopy() *GCPClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
+	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make(apiv1beta1.FailureDomains, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.Network.DeepCopyInto(&out.Network)
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(BastionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IAPEndpoint != nil {
+		in, out := &in.IAPEndpoint, &out.IAPEndpoint
+		*out = new(IAPEndpointStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
+func (in *GCPClusterStatus) DeepCopy() *GCPClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplate) DeepCopyInto(out *GCPClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplate.
+func (in *GCPClusterTemplate) DeepCopy() *GCPClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateList) DeepCopyInto(out *GCPClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateList.
+func (in *GCPClusterTemplateList) DeepCopy() *GCPClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateResource) DeepCopyInto(out *GCPClusterTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateResource.
+func (in *GCPClusterTemplateResource) DeepCopy() *GCPClusterTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateSpec) DeepCopyInto(out *GCPClusterTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateSpec.
+func (in *GCPClusterTemplateSpec) DeepCopy() *GCPClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPIdentityReference) DeepCopyInto(out *GCPIdentityReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPIdentityReference.
+func (in *GCPIdentityReference) DeepCopy() *GCPIdentityReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPIdentityReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachine.
+func (in *GCPMachine) DeepCopy() *GCPMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineList) DeepCopyInto(out *GCPMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineList.
+func (in *GCPMachineList) DeepCopy() *GCPMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePool) DeepCopyInto(out *GCPMachinePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePool.
+func (in *GCPMachinePool) DeepCopy() *GCPMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolList) DeepCopyInto(out *GCPMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolList.
+func (in *GCPMachinePoolList) DeepCopy() *GCPMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolSpec) DeepCopyInto(out *GCPMachinePoolSpec) {
+	*out = *in
+	if in.ProviderIDList != nil {
+		in, out := &in.ProviderIDList, &out.ProviderIDList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolSpec.
+func (in *GCPMachinePoolSpec) DeepCopy() *GCPMachinePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolStatus) DeepCopyInto(out *GCPMachinePoolStatus) {
+	*out = *in
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachineStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolStatus.
+func (in *GCPMachinePoolStatus) DeepCopy() *GCPMachinePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
+	*out = *in
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProviderID != nil {
+		in, out := &in.ProviderID, &out.ProviderID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageFamily != nil {
+		in, out := &in.ImageFamily, &out.ImageFamily
+		*out = new(string)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdditionalMetadata != nil {
+		in, out := &in.AdditionalMetadata, &out.AdditionalMetadata
+		*out = make([]MetadataItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AdditionalNetworkTags != nil {
+		in, out := &in.AdditionalNetworkTags, &out.AdditionalNetworkTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RootDeviceType != nil {
+		in, out := &in.RootDeviceType, &out.RootDeviceType
+		*out = new(DiskType)
+		**out = **in
+	}
+	if in.RootDiskEncryptionKey != nil {
+		in, out := &in.RootDiskEncryptionKey, &out.RootDiskEncryptionKey
+		*out = new(CustomerEncryptionKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]AttachedDiskSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisioningModel != nil {
+		in, out := &in.ProvisioningModel, &out.ProvisioningModel
+		*out = new(ProvisioningModel)
+		**out = **in
+	}
+	if in.InstanceTerminationAction != nil {
+		in, out := &in.InstanceTerminationAction, &out.InstanceTerminationAction
+		*out = new(InstanceTerminationAction)
+		**out = **in
+	}
+	if in.MaxRunDuration != nil {
+		in, out := &in.MaxRunDuration, &out.MaxRunDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GuestAccelerators != nil {
+		in, out := &in.GuestAccelerators, &out.GuestAccelerators
+		*out = make([]Accelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShieldedInstanceConfig != nil {
+		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
+		*out = new(GCPShieldedInstanceConfig)
+		**out = **in
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(ConfidentialComputePolicy)
+		**out = **in
+	}
+	if in.IPForwarding != nil {
+		in, out := &in.IPForwarding, &out.IPForwarding
+		*out = new(IPForwarding)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineSpec.
+func (in *GCPMachineSpec) DeepCopy() *GCPMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]v1.NodeAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceStatus != nil {
+		in, out := &in.InstanceStatus, &out.InstanceStatus
+		*out = new(InstanceStatus)
+		**out = **in
+	}
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]Accelerator, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShieldedInstanceConfig != nil {
+		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
+		*out = new(GCPShieldedInstanceConfig)
+		**out = **in
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(ConfidentialComputePolicy)
+		**out = **in
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachineStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskSpec.
-func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineStatus.
+func (in *GCPMachineStatus) DeepCopy() *GCPMachineStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AttachedDiskSpec)
+	out := new(GCPMachineStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildParams) DeepCopyInto(out *BuildParams) {
+func (in *GCPMachineTemplate) DeepCopyInto(out *GCPMachineTemplate) {
 	*out = *in
-	if in.Role != nil {
-		in, out := &in.Role, &out.Role
-		*out = new(string)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplate.
+func (in *GCPMachineTemplate) DeepCopy() *GCPMachineTemplate {
+	if in == nil {
+		return nil
 	}
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out := new(GCPMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplateList) DeepCopyInto(out *GCPMachineTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachineTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildParams.
-func (in *BuildParams) DeepCopy() *BuildParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateList.
+func (in *GCPMachineTemplateList) DeepCopy() *GCPMachineTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildParams)
+	out := new(GCPMachineTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Filter) DeepCopyInto(out *Filter) {
+func (in *GCPMachineTemplateResource) DeepCopyInto(out *GCPMachineTemplateResource) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateResource.
+func (in *GCPMachineTemplateResource) DeepCopy() *GCPMachineTemplateResource {
+	if in == nil {
+		return nil
 	}
+	out := new(GCPMachineTemplateResource)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
-func (in *Filter) DeepCopy() *Filter {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplateSpec) DeepCopyInto(out *GCPMachineTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateSpec.
+func (in *GCPMachineTemplateSpec) DeepCopy() *GCPMachineTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Filter)
+	out := new(GCPMachineTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
+func (in *GCPManagedCluster) DeepCopyInto(out *GCPManagedCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -109,18 +1151,18 @@ func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCluster.
-func (in *GCPCluster) DeepCopy() *GCPCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedCluster.
+func (in *GCPManagedCluster) DeepCopy() *GCPManagedCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPCluster)
+	out := new(GCPManagedCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPCluster) DeepCopyObject() runtime.Object {
+func (in *GCPManagedCluster) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -128,31 +1170,31 @@ func (in *GCPCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterList) DeepCopyInto(out *GCPClusterList) {
+func (in *GCPManagedClusterList) DeepCopyInto(out *GCPManagedClusterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPCluster, len(*in))
+		*out = make([]GCPManagedCluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterList.
-func (in *GCPClusterList) DeepCopy() *GCPClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterList.
+func (in *GCPManagedClusterList) DeepCopy() *GCPManagedClusterList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterList)
+	out := new(GCPManagedClusterList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterList) DeepCopyObject() runtime.Object {
+func (in *GCPManagedClusterList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -160,15 +1202,10 @@ func (in *GCPClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
+func (in *GCPManagedClusterSpec) DeepCopyInto(out *GCPManagedClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	in.Network.DeepCopyInto(&out.Network)
-	if in.FailureDomains != nil {
-		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	if in.AdditionalLabels != nil {
 		in, out := &in.AdditionalLabels, &out.AdditionalLabels
 		*out = make(Labels, len(*in))
@@ -176,61 +1213,67 @@ func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(GCPIdentityReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
-func (in *GCPClusterSpec) DeepCopy() *GCPClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterSpec.
+func (in *GCPManagedClusterSpec) DeepCopy() *GCPManagedClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterSpec)
+	out := new(GCPManagedClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
+func (in *GCPManagedClusterStatus) DeepCopyInto(out *GCPManagedClusterStatus) {
 	*out = *in
-	if in.FailureDomains != nil {
-		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make(apiv1beta1.FailureDomains, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+	in.Network.DeepCopyInto(&out.Network)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Network.DeepCopyInto(&out.Network)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
-func (in *GCPClusterStatus) DeepCopy() *GCPClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterStatus.
+func (in *GCPManagedClusterStatus) DeepCopy() *GCPManagedClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterStatus)
+	out := new(GCPManagedClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplate) DeepCopyInto(out *GCPClusterTemplate) {
+func (in *GCPManagedControlPlane) DeepCopyInto(out *GCPManagedControlPlane) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplate.
-func (in *GCPClusterTemplate) DeepCopy() *GCPClusterTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlane.
+func (in *GCPManagedControlPlane) DeepCopy() *GCPManagedControlPlane {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplate)
+	out := new(GCPManagedControlPlane)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterTemplate) DeepCopyObject() runtime.Object {
+func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -238,31 +1281,31 @@ func (in *GCPClusterTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateList) DeepCopyInto(out *GCPClusterTemplateList) {
+func (in *GCPManagedControlPlaneList) DeepCopyInto(out *GCPManagedControlPlaneList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPClusterTemplate, len(*in))
+		*out = make([]GCPManagedControlPlane, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateList.
-func (in *GCPClusterTemplateList) DeepCopy() *GCPClusterTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneList.
+func (in *GCPManagedControlPlaneList) DeepCopy() *GCPManagedControlPlaneList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateList)
+	out := new(GCPManagedControlPlaneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterTemplateList) DeepCopyObject() runtime.Object {
+func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -270,40 +1313,55 @@ func (in *GCPClusterTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateResource) DeepCopyInto(out *GCPClusterTemplateResource) {
+func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSpec) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.ReleaseChannel != nil {
+		in, out := &in.ReleaseChannel, &out.ReleaseChannel
+		*out = new(ReleaseChannel)
+		**out = **in
+	}
+	if in.ControlPlaneVersion != nil {
+		in, out := &in.ControlPlaneVersion, &out.ControlPlaneVersion
+		*out = new(string)
+		**out = **in
+	}
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateResource.
-func (in *GCPClusterTemplateResource) DeepCopy() *GCPClusterTemplateResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
+func (in *GCPManagedControlPlaneSpec) DeepCopy() *GCPManagedControlPlaneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateResource)
+	out := new(GCPManagedControlPlaneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateSpec) DeepCopyInto(out *GCPClusterTemplateSpec) {
+func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlaneStatus) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateSpec.
-func (in *GCPClusterTemplateSpec) DeepCopy() *GCPClusterTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
+func (in *GCPManagedControlPlaneStatus) DeepCopy() *GCPManagedControlPlaneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateSpec)
+	out := new(GCPManagedControlPlaneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
+func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -311,18 +1369,18 @@ func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachine.
-func (in *GCPMachine) DeepCopy() *GCPMachine {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePool.
+func (in *GCPManagedMachinePool) DeepCopy() *GCPManagedMachinePool {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachine)
+	out := new(GCPManagedMachinePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachine) DeepCopyObject() runtime.Object {
+func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -330,31 +1388,31 @@ func (in *GCPMachine) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineList) DeepCopyInto(out *GCPMachineList) {
+func (in *GCPManagedMachinePoolList) DeepCopyInto(out *GCPManagedMachinePoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPMachine, len(*in))
+		*out = make([]GCPManagedMachinePool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineList.
-func (in *GCPMachineList) DeepCopy() *GCPMachineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolList.
+func (in *GCPManagedMachinePoolList) DeepCopy() *GCPManagedMachinePoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineList)
+	out := new(GCPManagedMachinePoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineList) DeepCopyObject() runtime.Object {
+func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -362,231 +1420,308 @@ func (in *GCPMachineList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
+func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec) {
 	*out = *in
-	if in.Subnet != nil {
-		in, out := &in.Subnet, &out.Subnet
+	if in.MachineType != nil {
+		in, out := &in.MachineType, &out.MachineType
 		*out = new(string)
 		**out = **in
 	}
-	if in.ProviderID != nil {
-		in, out := &in.ProviderID, &out.ProviderID
-		*out = new(string)
+	if in.DiskSizeGB != nil {
+		in, out := &in.DiskSizeGB, &out.DiskSizeGB
+		*out = new(int64)
 		**out = **in
 	}
-	if in.ImageFamily != nil {
-		in, out := &in.ImageFamily, &out.ImageFamily
-		*out = new(string)
+	if in.DiskType != nil {
+		in, out := &in.DiskType, &out.DiskType
+		*out = new(DiskType)
 		**out = **in
 	}
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(string)
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(NodePoolAutoScaling)
 		**out = **in
 	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(Labels, len(*in))
+	if in.KubernetesLabels != nil {
+		in, out := &in.KubernetesLabels, &out.KubernetesLabels
+		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.AdditionalMetadata != nil {
-		in, out := &in.AdditionalMetadata, &out.AdditionalMetadata
-		*out = make([]MetadataItem, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KubernetesTaints != nil {
+		in, out := &in.KubernetesTaints, &out.KubernetesTaints
+		*out = make(Taints, len(*in))
+		copy(*out, *in)
 	}
-	if in.PublicIP != nil {
-		in, out := &in.PublicIP, &out.PublicIP
-		*out = new(bool)
-		**out = **in
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(NodePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.AdditionalNetworkTags != nil {
-		in, out := &in.AdditionalNetworkTags, &out.AdditionalNetworkTags
+	if in.ProviderIDList != nil {
+		in, out := &in.ProviderIDList, &out.ProviderIDList
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.RootDeviceType != nil {
-		in, out := &in.RootDeviceType, &out.RootDeviceType
-		*out = new(DiskType)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolSpec.
+func (in *GCPManagedMachinePoolSpec) DeepCopy() *GCPManagedMachinePoolSpec {
+	if in == nil {
+		return nil
 	}
-	if in.AdditionalDisks != nil {
-		in, out := &in.AdditionalDisks, &out.AdditionalDisks
-		*out = make([]AttachedDiskSpec, len(*in))
+	out := new(GCPManagedMachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolStatus) DeepCopyInto(out *GCPManagedMachinePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(ServiceAccount)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.IPForwarding != nil {
-		in, out := &in.IPForwarding, &out.IPForwarding
-		*out = new(IPForwarding)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolStatus.
+func (in *GCPManagedMachinePoolStatus) DeepCopy() *GCPManagedMachinePoolStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(GCPManagedMachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPShieldedInstanceConfig) DeepCopyInto(out *GCPShieldedInstanceConfig) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineSpec.
-func (in *GCPMachineSpec) DeepCopy() *GCPMachineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPShieldedInstanceConfig.
+func (in *GCPShieldedInstanceConfig) DeepCopy() *GCPShieldedInstanceConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineSpec)
+	out := new(GCPShieldedInstanceConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
+func (in *IAPEndpointStatus) DeepCopyInto(out *IAPEndpointStatus) {
 	*out = *in
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]v1.NodeAddress, len(*in))
-		copy(*out, *in)
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
 	}
-	if in.InstanceStatus != nil {
-		in, out := &in.InstanceStatus, &out.InstanceStatus
-		*out = new(InstanceStatus)
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
 		**out = **in
 	}
-	if in.FailureReason != nil {
-		in, out := &in.FailureReason, &out.FailureReason
-		*out = new(errors.MachineStatusError)
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(string)
 		**out = **in
 	}
-	if in.FailureMessage != nil {
-		in, out := &in.FailureMessage, &out.FailureMessage
+	if in.BackendService != nil {
+		in, out := &in.BackendService, &out.BackendService
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForwardingRule != nil {
+		in, out := &in.ForwardingRule, &out.ForwardingRule
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineStatus.
-func (in *GCPMachineStatus) DeepCopy() *GCPMachineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAPEndpointStatus.
+func (in *IAPEndpointStatus) DeepCopy() *IAPEndpointStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineStatus)
+	out := new(IAPEndpointStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplate) DeepCopyInto(out *GCPMachineTemplate) {
+func (in *IAPSpec) DeepCopyInto(out *IAPSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(bool)
+		**out = **in
+	}
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplate.
-func (in *GCPMachineTemplate) DeepCopy() *GCPMachineTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAPSpec.
+func (in *IAPSpec) DeepCopy() *IAPSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplate)
+	out := new(IAPSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineTemplate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Labels) DeepCopyInto(out *Labels) {
+	{
+		in := &in
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
+func (in Labels) DeepCopy() Labels {
+	if in == nil {
+		return nil
+	}
+	out := new(Labels)
+	in.DeepCopyInto(out)
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateList) DeepCopyInto(out *GCPMachineTemplateList) {
+func (in *LoadBalancerHealthCheck) DeepCopyInto(out *LoadBalancerHealthCheck) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPMachineTemplate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(HealthCheckProtocol)
+		**out = **in
+	}
+	if in.RequestPath != nil {
+		in, out := &in.RequestPath, &out.RequestPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CheckIntervalSec != nil {
+		in, out := &in.CheckIntervalSec, &out.CheckIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateList.
-func (in *GCPMachineTemplateList) DeepCopy() *GCPMachineTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheck.
+func (in *LoadBalancerHealthCheck) DeepCopy() *LoadBalancerHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateList)
+	out := new(LoadBalancerHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineTemplateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateResource) DeepCopyInto(out *GCPMachineTemplateResource) {
+func (in *LoadBalancerPort) DeepCopyInto(out *LoadBalancerPort) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateResource.
-func (in *GCPMachineTemplateResource) DeepCopy() *GCPMachineTemplateResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerPort.
+func (in *LoadBalancerPort) DeepCopy() *LoadBalancerPort {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateResource)
+	out := new(LoadBalancerPort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateSpec) DeepCopyInto(out *GCPMachineTemplateSpec) {
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
+	if in.LoadBalancerType != nil {
+		in, out := &in.LoadBalancerType, &out.LoadBalancerType
+		*out = new(LoadBalancerType)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReservedAddress != nil {
+		in, out := &in.ReservedAddress, &out.ReservedAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityPolicy != nil {
+		in, out := &in.SecurityPolicy, &out.SecurityPolicy
+		*out = new(SecurityPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalPorts != nil {
+		in, out := &in.AdditionalPorts, &out.AdditionalPorts
+		*out = make([]LoadBalancerPort, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateSpec.
-func (in *GCPMachineTemplateSpec) DeepCopy() *GCPMachineTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateSpec)
+	out := new(LoadBalancerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Labels) DeepCopyInto(out *Labels) {
-	{
-		in := &in
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+func (in *ManagedKey) DeepCopyInto(out *ManagedKey) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
-func (in Labels) DeepCopy() Labels {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedKey.
+func (in *ManagedKey) DeepCopy() *ManagedKey {
 	if in == nil {
 		return nil
 	}
-	out := new(Labels)
+	out := new(ManagedKey)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -629,6 +1764,13 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.APIServerAddress != nil {
 		in, out := &in.APIServerAddress, &out.APIServerAddress
 		*out = new(string)
@@ -661,6 +1803,18 @@ func (in *Network) DeepCopyInto(out *Network) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.APIServerSecurityPolicy != nil {
+		in, out := &in.APIServerSecurityPolicy, &out.APIServerSecurityPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalPortForwardingRules != nil {
+		in, out := &in.AdditionalPortForwardingRules, &out.AdditionalPortForwardingRules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
@@ -702,6 +1856,18 @@ func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.HostProject != nil {
+		in, out := &in.HostProject, &out.HostProject
+		*out = new(string)
+		**out = **in
+	}
+	if in.FirewallRules != nil {
+		in, out := &in.FirewallRules, &out.FirewallRules
+		*out = make(FirewallRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
@@ -714,6 +1880,71 @@ func (in *NetworkSpec) DeepCopy() *NetworkSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolAutoScaling) DeepCopyInto(out *NodePoolAutoScaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolAutoScaling.
+func (in *NodePoolAutoScaling) DeepCopy() *NodePoolAutoScaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolAutoScaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolUpgradeSettings) DeepCopyInto(out *NodePoolUpgradeSettings) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolUpgradeSettings.
+func (in *NodePoolUpgradeSettings) DeepCopy() *NodePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedSourceRanges != nil {
+		in, out := &in.AllowedSourceRanges, &out.AllowedSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
+func (in *SecurityPolicySpec) DeepCopy() *SecurityPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
@@ -795,3 +2026,62 @@ func (in Subnets) DeepCopy() Subnets {
 	in.DeepCopyInto(out)
 	return *out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SuppliedKey) DeepCopyInto(out *SuppliedKey) {
+	*out = *in
+	if in.RawKey != nil {
+		in, out := &in.RawKey, &out.RawKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RSAEncryptedKey != nil {
+		in, out := &in.RSAEncryptedKey, &out.RSAEncryptedKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SuppliedKey.
+func (in *SuppliedKey) DeepCopy() *SuppliedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SuppliedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Taint) DeepCopyInto(out *Taint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taint.
+func (in *Taint) DeepCopy() *Taint {
+	if in == nil {
+		return nil
+	}
+	out := new(Taint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Taints) DeepCopyInto(out *Taints) {
+	{
+		in := &in
+		*out = make(Taints, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Taints.
+func (in Taints) DeepCopy() Taints {
+	if in == nil {
+		return nil
+	}
+	out := new(Taints)
+	in.DeepCopyInto(out)
+	return *out
+}
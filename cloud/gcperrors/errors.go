@@ -19,8 +19,10 @@ package gcperrors
 
 import (
 	"net/http"
+	"strings"
 
 	"google.golang.org/api/googleapi"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 )
 
 // IsNotFound reports whether err is a Google API error
@@ -43,3 +45,79 @@ func IgnoreNotFound(err error) error {
 
 	return err
 }
+
+// IsQuotaExceeded reports whether err is a Google API error
+// caused by an exceeded project or regional quota.
+func IsQuotaExceeded(err error) bool {
+	return hasReason(err, "quotaExceeded", "QUOTA_EXCEEDED")
+}
+
+// IsResourceExhausted reports whether err is a Google API error
+// caused by a zone running out of the requested resources.
+func IsResourceExhausted(err error) bool {
+	return hasReason(err, "resourceExhausted", "ZONE_RESOURCE_POOL_EXHAUSTED")
+}
+
+// IsForbidden reports whether err is a Google API error
+// with http.StatusForbidden.
+func IsForbidden(err error) bool {
+	return hasCode(err, http.StatusForbidden) && !IsQuotaExceeded(err)
+}
+
+// IsBadRequest reports whether err is a Google API error
+// with http.StatusBadRequest.
+func IsBadRequest(err error) bool {
+	return hasCode(err, http.StatusBadRequest) && !IsQuotaExceeded(err) && !IsResourceExhausted(err)
+}
+
+// ReasonForError returns the condition reason matching a Google API error,
+// or the fallback reason.
+func ReasonForError(err error, fallback string) string {
+	switch {
+	case IsQuotaExceeded(err):
+		return infrav1.QuotaExceededReason
+	case IsResourceExhausted(err):
+		return infrav1.ZoneResourcesExhaustedReason
+	case IsForbidden(err):
+		return infrav1.PermissionDeniedReason
+	case IsBadRequest(err):
+		return infrav1.InvalidConfigurationReason
+	default:
+		return fallback
+	}
+}
+
+func hasCode(err error, code int) bool {
+	if err == nil {
+		return false
+	}
+	ae, ok := err.(*googleapi.Error)
+
+	return ok && ae.Code == code
+}
+
+// hasReason reports whether err is a Google API error carrying one of the reasons,
+// either in its error items or, for failed operations, as prefix of the message.
+func hasReason(err error, reasons ...string) bool {
+	if err == nil {
+		return false
+	}
+	ae, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+
+	for _, reason := range reasons {
+		for _, item := range ae.Errors {
+			if item.Reason == reason {
+				return true
+			}
+		}
+
+		if strings.HasPrefix(ae.Message, reason) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,12 +18,17 @@ package cloud
 
 import (
 	"context"
+	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Cloud alias for cloud.Cloud interface.
@@ -43,15 +48,19 @@ type Client interface {
 // ClusterGetter is an interface which can get cluster informations.
 type ClusterGetter interface {
 	Client
+	ComputeService() *compute.Service
 	Project() string
 	Region() string
 	Name() string
 	Namespace() string
 	NetworkName() string
+	NetworkProject() string
+	NetworkCloud() Cloud
 	Network() *infrav1.Network
 	AdditionalLabels() infrav1.Labels
 	FailureDomains() clusterv1.FailureDomains
 	ControlPlaneEndpoint() clusterv1.APIEndpoint
+	LoadBalancerType() infrav1.LoadBalancerType
 }
 
 // ClusterSetter is an interface which can set cluster informations.
@@ -74,6 +83,7 @@ type MachineGetter interface {
 	Project() string
 	Role() string
 	IsControlPlane() bool
+	IsControlPlaneLoadBalanced() bool
 	ControlPlaneGroupName() string
 	GetInstanceID() *string
 	GetProviderID() string
@@ -89,6 +99,11 @@ type MachineSetter interface {
 	SetFailureReason(v capierrors.MachineStatusError)
 	SetAnnotation(key, value string)
 	SetAddresses(addressList []corev1.NodeAddress)
+	SetAccelerators(accelerators []infrav1.Accelerator)
+	SetShieldedInstanceConfig(config *infrav1.GCPShieldedInstanceConfig)
+	SetConfidentialCompute(policy *infrav1.ConfidentialComputePolicy)
+	MarkConditionTrue(t clusterv1.ConditionType)
+	MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string)
 }
 
 // Machine is an interface which can get and set machine informations.
@@ -96,3 +111,105 @@ type Machine interface {
 	MachineGetter
 	MachineSetter
 }
+
+// MachinePoolGetter is an interface which can get machine pool informations.
+type MachinePoolGetter interface {
+	Client
+	ComputeService() *compute.Service
+	Name() string
+	Namespace() string
+	Project() string
+	Region() string
+	Zones() []string
+	Replicas() int64
+	GetBootstrapData() (string, error)
+	InstanceGroupName() string
+	InstanceTemplatePrefix() string
+	InstanceTemplateDescription() string
+	InstanceTemplateSpec(bootstrapData string) (*compute.InstanceTemplate, error)
+}
+
+// MachinePoolSetter is an interface which can set machine pool informations.
+type MachinePoolSetter interface {
+	SetReady()
+	SetNotReady()
+	SetReplicas(replicas int32)
+	SetProviderIDList(providerIDs []string)
+	SetInstanceTemplate(name string)
+	MarkConditionTrue(t clusterv1.ConditionType)
+	MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string)
+}
+
+// MachinePool is an interface which can get and set machine pool informations.
+type MachinePool interface {
+	MachinePoolGetter
+	MachinePoolSetter
+}
+
+// ManagedControlPlaneGetter is an interface which can get managed control plane informations.
+type ManagedControlPlaneGetter interface {
+	Client() client.Client
+	ContainerService() *container.Service
+	Name() string
+	Namespace() string
+	ClusterName() string
+	ClusterLocation() string
+	ClusterFullName() string
+	ClusterSpec() *container.Cluster
+	NodePoolSpecs(ctx context.Context) ([]*container.NodePool, error)
+	DesiredVersion() *string
+	DesiredReleaseChannel() string
+	KubeconfigToken(ctx context.Context) (string, time.Time, error)
+	KubeconfigOwnerRef() metav1.OwnerReference
+}
+
+// ManagedControlPlaneSetter is an interface which can set managed control plane informations.
+type ManagedControlPlaneSetter interface {
+	SetEndpoint(host string)
+	SetReady()
+	SetNotReady()
+	SetInitialized()
+	SetCurrentVersion(version string)
+	MarkConditionTrue(t clusterv1.ConditionType)
+	MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string)
+}
+
+// ManagedControlPlane is an interface which can get and set managed control plane informations.
+type ManagedControlPlane interface {
+	ManagedControlPlaneGetter
+	ManagedControlPlaneSetter
+}
+
+// ManagedMachinePoolGetter is an interface which can get managed machine pool informations.
+type ManagedMachinePoolGetter interface {
+	ComputeService() *compute.Service
+	ContainerService() *container.Service
+	Name() string
+	Namespace() string
+	NodePoolName() string
+	NodePoolFullName() string
+	ClusterFullName() string
+	NodePoolSpec(zones int) *container.NodePool
+	DesiredVersion() *string
+	NodeCountPerZone(zones int) int64
+	NodePoolAutoscaling() *container.NodePoolAutoscaling
+	NodePoolLabels() map[string]string
+	NodePoolTaints() []*container.NodeTaint
+	NodePoolUpgradeSettings() *container.UpgradeSettings
+}
+
+// ManagedMachinePoolSetter is an interface which can set managed machine pool informations.
+type ManagedMachinePoolSetter interface {
+	SetReady()
+	SetNotReady()
+	SetReplicas(replicas int32)
+	SetProviderIDList(providerIDs []string)
+	MarkConditionTrue(t clusterv1.ConditionType)
+	MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string)
+}
+
+// ManagedMachinePool is an interface which can get and set managed machine pool informations.
+type ManagedMachinePool interface {
+	ManagedMachinePoolGetter
+	ManagedMachinePoolSetter
+}
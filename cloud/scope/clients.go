@@ -22,12 +22,14 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
 	"k8s.io/client-go/util/flowcontrol"
 )
 
 // GCPServices contains all the gcp services used by the scopes.
 type GCPServices struct {
-	Compute *compute.Service
+	Compute   *compute.Service
+	Container *container.Service
 }
 
 // GCPRateLimiter implements cloud.RateLimiter.
@@ -19,15 +19,21 @@ package scope
 import (
 	"context"
 	"fmt"
+	"math"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -50,12 +56,12 @@ func NewClusterScope(params ClusterScopeParams) (*ClusterScope, error) {
 		return nil, errors.New("failed to generate new scope from nil GCPCluster")
 	}
 
-	computeSvc, err := compute.NewService(context.TODO())
-	if err != nil {
-		return nil, errors.Errorf("failed to create gcp compute client: %v", err)
-	}
-
 	if params.GCPServices.Compute == nil {
+		computeSvc, err := newComputeService(context.TODO(), params.Client, params.GCPCluster)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
+		}
+
 		params.GCPServices.Compute = computeSvc
 	}
 
@@ -90,6 +96,16 @@ func (s *ClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// NetworkCloud returns initialized cloud for the project hosting the network.
+func (s *ClusterScope) NetworkCloud() cloud.Cloud {
+	return newCloud(s.NetworkProject(), s.GCPServices)
+}
+
+// ComputeService returns the compute service used for the resources not supported by cloud.Cloud.
+func (s *ClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
 // Project returns the current project name.
 func (s *ClusterScope) Project() string {
 	return s.GCPCluster.Spec.Project
@@ -115,9 +131,19 @@ func (s *ClusterScope) NetworkName() string {
 	return pointer.StringDeref(s.GCPCluster.Spec.Network.Name, "default")
 }
 
+// NetworkProject returns the project name where the network resides.
+func (s *ClusterScope) NetworkProject() string {
+	return pointer.StringDeref(s.GCPCluster.Spec.Network.HostProject, s.Project())
+}
+
+// IsSharedVpc returns true if the network resides in a host project other than the cluster project.
+func (s *ClusterScope) IsSharedVpc() bool {
+	return s.NetworkProject() != s.Project()
+}
+
 // NetworkLink returns the partial URL for the network.
 func (s *ClusterScope) NetworkLink() string {
-	return fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), s.NetworkName())
+	return fmt.Sprintf("projects/%s/global/networks/%s", s.NetworkProject(), s.NetworkName())
 }
 
 // Network returns the cluster network object.
@@ -133,10 +159,39 @@ func (s *ClusterScope) AdditionalLabels() infrav1.Labels {
 // ControlPlaneEndpoint returns the cluster control-plane endpoint.
 func (s *ClusterScope) ControlPlaneEndpoint() clusterv1.APIEndpoint {
 	endpoint := s.GCPCluster.Spec.ControlPlaneEndpoint
+	if s.LoadBalancerType() == infrav1.None {
+		// The endpoint is provided by the user.
+		return endpoint
+	}
+
 	endpoint.Port = pointer.Int32Deref(s.Cluster.Spec.ClusterNetwork.APIServerPort, 443)
+	if s.LoadBalancerType().IsRegional() {
+		// Passthrough load balancers can not translate ports, clients connect to the backend port.
+		endpoint.Port = s.LoadBalancerBackendPort()
+	}
 	return endpoint
 }
 
+// LoadBalancerType returns the type of load balancer created for the API server.
+func (s *ClusterScope) LoadBalancerType() infrav1.LoadBalancerType {
+	if t := s.GCPCluster.Spec.LoadBalancer.LoadBalancerType; t != nil {
+		return *t
+	}
+
+	return infrav1.External
+}
+
+// LoadBalancerReservedAddress returns the name or IP address of the existing address of the load balancer,
+// or an empty string when the address is reserved for the cluster.
+func (s *ClusterScope) LoadBalancerReservedAddress() string {
+	return pointer.StringDeref(s.GCPCluster.Spec.LoadBalancer.ReservedAddress, "")
+}
+
+// LoadBalancerBackendPort returns the port the API server listens on behind the load balancer.
+func (s *ClusterScope) LoadBalancerBackendPort() int32 {
+	return pointer.Int32Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
+}
+
 // FailureDomains returns the cluster failure domains.
 func (s *ClusterScope) FailureDomains() clusterv1.FailureDomains {
 	return s.GCPCluster.Status.FailureDomains
@@ -167,30 +222,17 @@ func (s *ClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint) {
 
 // NetworkSpec returns google compute network spec.
 func (s *ClusterScope) NetworkSpec() *compute.Network {
-	createSubnet := pointer.BoolDeref(s.GCPCluster.Spec.Network.AutoCreateSubnetworks, true)
-	network := &compute.Network{
-		Name:                  s.NetworkName(),
-		Description:           infrav1.ClusterTagKey(s.Name()),
-		AutoCreateSubnetworks: createSubnet,
-		ForceSendFields:       []string{"AutoCreateSubnetworks"},
-	}
+	return networkSpec(s.Name(), s.NetworkName(), s.GCPCluster.Spec.Network)
+}
 
-	return network
+// SubnetSpecs returns google compute subnets spec.
+func (s *ClusterScope) SubnetSpecs() []*compute.Subnetwork {
+	return subnetSpecs(s.Name(), s.Region(), s.GCPCluster.Spec.Network)
 }
 
 // NatRouterSpec returns google compute nat router spec.
 func (s *ClusterScope) NatRouterSpec() *compute.Router {
-	networkSpec := s.NetworkSpec()
-	return &compute.Router{
-		Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
-		Nats: []*compute.RouterNat{
-			{
-				Name:                          fmt.Sprintf("%s-%s", networkSpec.Name, "nat"),
-				NatIpAllocateOption:           "AUTO_ONLY",
-				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
-			},
-		},
-	}
+	return natRouterSpec(s.NetworkName())
 }
 
 // ANCHOR_END: ClusterNetworkSpec
@@ -199,6 +241,15 @@ func (s *ClusterScope) NatRouterSpec() *compute.Router {
 
 // FirewallRulesSpec returns google compute firewall spec.
 func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
+	healthCheckSourceRanges := []string{
+		"35.191.0.0/16",
+		"130.211.0.0/22",
+	}
+	if s.LoadBalancerType() == infrav1.RegionalExternal {
+		// The health checks of the external passthrough load balancers also come from these ranges.
+		healthCheckSourceRanges = append(healthCheckSourceRanges, "209.85.152.0/22", "209.85.204.0/22")
+	}
+
 	firewallRules := []*compute.Firewall{
 		{
 			Name:    fmt.Sprintf("allow-%s-healthchecks", s.Name()),
@@ -206,16 +257,12 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 			Allowed: []*compute.FirewallAllowed{
 				{
 					IPProtocol: "TCP",
-					Ports: []string{
-						strconv.FormatInt(6443, 10),
-					},
+					Ports:      s.loadBalancerBackendPorts(),
 				},
 			},
-			Direction: "INGRESS",
-			SourceRanges: []string{
-				"35.191.0.0/16",
-				"130.211.0.0/22",
-			},
+			Direction:    "INGRESS",
+			Priority:     1000,
+			SourceRanges: healthCheckSourceRanges,
 			TargetTags: []string{
 				fmt.Sprintf("%s-control-plane", s.Name()),
 			},
@@ -229,6 +276,7 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 				},
 			},
 			Direction: "INGRESS",
+			Priority:  1000,
 			SourceTags: []string{
 				fmt.Sprintf("%s-control-plane", s.Name()),
 				fmt.Sprintf("%s-node", s.Name()),
@@ -240,24 +288,307 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 		},
 	}
 
+	for _, rule := range s.GCPCluster.Spec.Network.FirewallRules {
+		firewallRules = append(firewallRules, s.firewallRuleSpec(rule))
+	}
+
+	if bastion := s.GCPCluster.Spec.Bastion; bastion != nil {
+		firewallRules = append(firewallRules,
+			s.firewallRuleSpec(infrav1.FirewallRule{
+				Name:         infrav1.BastionFirewallRuleName,
+				Protocols:    []infrav1.FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}},
+				SourceRanges: bastion.AllowedSourceRanges,
+				TargetTags:   []string{s.bastionTag()},
+			}),
+			s.firewallRuleSpec(infrav1.FirewallRule{
+				Name:       infrav1.BastionNodesFirewallRuleName,
+				Protocols:  []infrav1.FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}},
+				SourceTags: []string{s.bastionTag()},
+				TargetTags: []string{
+					fmt.Sprintf("%s-control-plane", s.Name()),
+					fmt.Sprintf("%s-node", s.Name()),
+				},
+			}),
+		)
+	}
+
+	if s.LoadBalancerType().IsRegional() {
+		// Passthrough load balancers preserve the client source addresses.
+		firewallRules = append(firewallRules, s.firewallRuleSpec(infrav1.FirewallRule{
+			Name: infrav1.APIServerFirewallRuleName,
+			Protocols: []infrav1.FirewallRuleProtocol{{
+				Protocol: "tcp",
+				Ports:    s.loadBalancerBackendPorts(),
+			}},
+			SourceRanges: s.apiServerSourceRanges(),
+			TargetTags:   []string{fmt.Sprintf("%s-control-plane", s.Name())},
+		}))
+	}
+
+	if iap := s.GCPCluster.Spec.IAP; iap != nil {
+		if pointer.BoolDeref(iap.SSH, true) {
+			firewallRules = append(firewallRules, s.firewallRuleSpec(infrav1.FirewallRule{
+				Name:         infrav1.IAPSSHFirewallRuleName,
+				Protocols:    []infrav1.FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}},
+				SourceRanges: []string{infrav1.IAPSourceRange},
+				TargetTags: []string{
+					fmt.Sprintf("%s-control-plane", s.Name()),
+					fmt.Sprintf("%s-node", s.Name()),
+				},
+			}))
+		}
+
+		if pointer.BoolDeref(iap.APIServer, true) {
+			firewallRules = append(firewallRules, s.firewallRuleSpec(infrav1.FirewallRule{
+				Name: infrav1.IAPAPIServerFirewallRuleName,
+				Protocols: []infrav1.FirewallRuleProtocol{{
+					Protocol: "tcp",
+					Ports:    []string{strconv.FormatInt(int64(s.LoadBalancerBackendPort()), 10)},
+				}},
+				SourceRanges: []string{infrav1.IAPSourceRange},
+				TargetTags:   []string{fmt.Sprintf("%s-control-plane", s.Name())},
+			}))
+		}
+	}
+
 	return firewallRules
 }
 
+// FirewallRulePrefix returns the name prefix of the user-defined firewall rules of the cluster.
+func (s *ClusterScope) FirewallRulePrefix() string {
+	return fmt.Sprintf("%s-", s.Name())
+}
+
+// firewallRuleSpec returns the google compute firewall spec of a user-defined firewall rule.
+// The rule is marked as owned by the cluster through its description.
+func (s *ClusterScope) firewallRuleSpec(rule infrav1.FirewallRule) *compute.Firewall {
+	firewall := &compute.Firewall{
+		Name:                  s.FirewallRulePrefix() + rule.Name,
+		Description:           infrav1.ClusterTagKey(s.Name()),
+		Network:               s.NetworkLink(),
+		Direction:             string(infrav1.FirewallRuleDirectionIngress),
+		Priority:              int64(pointer.Int32Deref(rule.Priority, 1000)),
+		SourceRanges:          rule.SourceRanges,
+		DestinationRanges:     rule.DestinationRanges,
+		SourceTags:            rule.SourceTags,
+		TargetTags:            rule.TargetTags,
+		SourceServiceAccounts: rule.SourceServiceAccounts,
+		TargetServiceAccounts: rule.TargetServiceAccounts,
+		ForceSendFields:       []string{"Priority"},
+	}
+
+	if rule.Direction != nil {
+		firewall.Direction = string(*rule.Direction)
+	}
+
+	// GCE matches any address when a rule has no source, or no destination for egress rules, and reports it
+	// as 0.0.0.0/0. The default is set here so the rule read back matches its spec.
+	if firewall.Direction == string(infrav1.FirewallRuleDirectionEgress) {
+		if len(firewall.DestinationRanges) == 0 {
+			firewall.DestinationRanges = []string{"0.0.0.0/0"}
+		}
+	} else if len(firewall.SourceRanges) == 0 && len(firewall.SourceTags) == 0 && len(firewall.SourceServiceAccounts) == 0 {
+		firewall.SourceRanges = []string{"0.0.0.0/0"}
+	}
+
+	deny := rule.Action != nil && *rule.Action == infrav1.FirewallRuleActionDeny
+	for _, protocol := range rule.Protocols {
+		if deny {
+			firewall.Denied = append(firewall.Denied, &compute.FirewallDenied{IPProtocol: protocol.Protocol, Ports: protocol.Ports})
+		} else {
+			firewall.Allowed = append(firewall.Allowed, &compute.FirewallAllowed{IPProtocol: protocol.Protocol, Ports: protocol.Ports})
+		}
+	}
+
+	return firewall
+}
+
 // ANCHOR_END: ClusterFirewallSpec
 
+// autoModeSubnetworksRange is the range of the subnetworks GCE creates in the networks in auto mode.
+const autoModeSubnetworksRange = "10.128.0.0/9"
+
+// apiServerSourceRanges returns the source ranges allowed to reach the API server through a passthrough load balancer.
+// The internal load balancer is reachable from the subnetworks of the cluster by default, or from the range of the
+// subnetworks of auto mode networks when the spec declares none.
+func (s *ClusterScope) apiServerSourceRanges() []string {
+	if ranges := s.GCPCluster.Spec.LoadBalancer.AllowedSourceRanges; len(ranges) > 0 {
+		return ranges
+	}
+
+	if s.LoadBalancerType() == infrav1.RegionalExternal {
+		return []string{"0.0.0.0/0"}
+	}
+
+	ranges := []string{}
+	for _, subnet := range s.GCPCluster.Spec.Network.Subnets {
+		if subnet.CidrBlock != "" {
+			ranges = append(ranges, subnet.CidrBlock)
+		}
+	}
+	if len(ranges) == 0 {
+		ranges = append(ranges, autoModeSubnetworksRange)
+	}
+
+	return ranges
+}
+
+// ANCHOR: ClusterBastionSpec
+
+// BastionInstanceName returns the name of the bastion instance.
+func (s *ClusterScope) BastionInstanceName() string {
+	return fmt.Sprintf("%s-bastion", s.Name())
+}
+
+// BastionZone returns the zone of the bastion instance, by default the first failure domain of the cluster.
+func (s *ClusterScope) BastionZone() string {
+	if bastion := s.GCPCluster.Spec.Bastion; bastion != nil && bastion.Zone != nil {
+		return *bastion.Zone
+	}
+
+	zones := make([]string, 0, len(s.FailureDomains()))
+	for zone := range s.FailureDomains() {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	if len(zones) == 0 {
+		return ""
+	}
+
+	return zones[0]
+}
+
+// BastionSpec returns google compute instance spec of the bastion host, or nil when the cluster has no bastion host.
+func (s *ClusterScope) BastionSpec() *compute.Instance {
+	bastion := s.GCPCluster.Spec.Bastion
+	if bastion == nil {
+		return nil
+	}
+
+	zone := s.BastionZone()
+	instance := &compute.Instance{
+		Name:        s.BastionInstanceName(),
+		Zone:        zone,
+		MachineType: path.Join("zones", zone, "machineTypes", pointer.StringDeref(bastion.MachineType, "e2-micro")),
+		Tags: &compute.Tags{
+			Items: []string{s.bastionTag()},
+		},
+		Labels: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Role:        pointer.String("bastion"),
+			Additional:  s.AdditionalLabels(),
+		}),
+		Disks: []*compute.AttachedDisk{
+			{
+				AutoDelete: true,
+				Boot:       true,
+				InitializeParams: &compute.AttachedDiskInitializeParams{
+					DiskType:    path.Join("zones", zone, "diskTypes", string(infrav1.PdStandardDiskType)),
+					SourceImage: pointer.StringDeref(bastion.Image, "projects/debian-cloud/global/images/family/debian-11"),
+				},
+			},
+		},
+		NetworkInterfaces: []*compute.NetworkInterface{
+			{
+				Network: path.Join("projects", s.NetworkProject(), "global", "networks", s.NetworkName()),
+			},
+		},
+		Metadata: &compute.Metadata{},
+	}
+
+	if bastion.Subnet != nil {
+		instance.NetworkInterfaces[0].Subnetwork = path.Join("projects", s.NetworkProject(), "regions", s.Region(), "subnetworks", *bastion.Subnet)
+	}
+
+	if pointer.BoolDeref(bastion.PublicIP, true) {
+		instance.NetworkInterfaces[0].AccessConfigs = []*compute.AccessConfig{
+			{
+				Type: "ONE_TO_ONE_NAT",
+				Name: "External NAT",
+			},
+		}
+	}
+
+	if len(bastion.SSHKeys) > 0 {
+		instance.Metadata.Items = append(instance.Metadata.Items, &compute.MetadataItems{
+			Key:   "ssh-keys",
+			Value: pointer.String(strings.Join(bastion.SSHKeys, "\n")),
+		})
+	} else {
+		instance.Metadata.Items = append(instance.Metadata.Items, &compute.MetadataItems{
+			Key:   "enable-oslogin",
+			Value: pointer.String("TRUE"),
+		})
+	}
+
+	return instance
+}
+
+// BastionStatus returns the observed state of the bastion host.
+func (s *ClusterScope) BastionStatus() *infrav1.BastionStatus {
+	return s.GCPCluster.Status.Bastion
+}
+
+// SetBastionStatus sets the observed state of the bastion host.
+func (s *ClusterScope) SetBastionStatus(status *infrav1.BastionStatus) {
+	s.GCPCluster.Status.Bastion = status
+}
+
+// bastionTag returns the network tag of the bastion instance.
+func (s *ClusterScope) bastionTag() string {
+	return fmt.Sprintf("%s-bastion", s.Name())
+}
+
+// ANCHOR_END: ClusterBastionSpec
+
 // ANCHOR: ClusterControlPlaneSpec
 
 // AddressSpec returns google compute address spec.
 func (s *ClusterScope) AddressSpec() *compute.Address {
-	return &compute.Address{
+	address := &compute.Address{
 		Name:        fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
 		AddressType: "EXTERNAL",
 		IpVersion:   "IPV4",
 	}
+
+	if s.LoadBalancerType() == infrav1.Internal {
+		address.AddressType = "INTERNAL"
+		address.IpVersion = ""
+		address.Purpose = "GCE_ENDPOINT"
+		address.Region = s.Region()
+		address.Subnetwork = s.loadBalancerSubnetLink()
+	}
+
+	if s.LoadBalancerType() == infrav1.RegionalExternal {
+		address.IpVersion = ""
+		address.Region = s.Region()
+	}
+
+	return address
 }
 
 // BackendServiceSpec returns google compute backend-service spec.
 func (s *ClusterScope) BackendServiceSpec() *compute.BackendService {
+	if s.LoadBalancerType() == infrav1.Internal {
+		return &compute.BackendService{
+			Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+			LoadBalancingScheme: "INTERNAL",
+			Protocol:            "TCP",
+			Region:              s.Region(),
+		}
+	}
+
+	if s.LoadBalancerType() == infrav1.RegionalExternal {
+		return &compute.BackendService{
+			Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+			LoadBalancingScheme: "EXTERNAL",
+			Protocol:            "TCP",
+			Region:              s.Region(),
+		}
+	}
+
 	return &compute.BackendService{
 		Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
 		LoadBalancingScheme: "EXTERNAL",
@@ -269,6 +600,28 @@ func (s *ClusterScope) BackendServiceSpec() *compute.BackendService {
 
 // ForwardingRuleSpec returns google compute forwarding-rule spec.
 func (s *ClusterScope) ForwardingRuleSpec() *compute.ForwardingRule {
+	if s.LoadBalancerType() == infrav1.Internal {
+		return &compute.ForwardingRule{
+			Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+			IPProtocol:          "TCP",
+			LoadBalancingScheme: "INTERNAL",
+			Ports:               []string{strconv.FormatInt(int64(s.LoadBalancerBackendPort()), 10)},
+			Network:             s.NetworkLink(),
+			Subnetwork:          s.loadBalancerSubnetLink(),
+			Region:              s.Region(),
+		}
+	}
+
+	if s.LoadBalancerType() == infrav1.RegionalExternal {
+		return &compute.ForwardingRule{
+			Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+			IPProtocol:          "TCP",
+			LoadBalancingScheme: "EXTERNAL",
+			Ports:               []string{strconv.FormatInt(int64(s.LoadBalancerBackendPort()), 10)},
+			Region:              s.Region(),
+		}
+	}
+
 	port := pointer.Int32Deref(s.Cluster.Spec.ClusterNetwork.APIServerPort, 443)
 	portRange := fmt.Sprintf("%d-%d", port, port)
 	return &compute.ForwardingRule{
@@ -281,32 +634,62 @@ func (s *ClusterScope) ForwardingRuleSpec() *compute.ForwardingRule {
 
 // HealthCheckSpec returns google compute health-check spec.
 func (s *ClusterScope) HealthCheckSpec() *compute.HealthCheck {
-	return &compute.HealthCheck{
-		Name: fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
-		Type: "SSL",
-		SslHealthCheck: &compute.SSLHealthCheck{
-			Port:              6443,
+	spec := s.GCPCluster.Spec.LoadBalancer.HealthCheck
+	if spec == nil {
+		spec = &infrav1.LoadBalancerHealthCheck{}
+	}
+
+	healthCheck := &compute.HealthCheck{
+		Name:               fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Type:               string(infrav1.HealthCheckProtocolSSL),
+		CheckIntervalSec:   pointer.Int64Deref(spec.CheckIntervalSec, 10),
+		TimeoutSec:         pointer.Int64Deref(spec.TimeoutSec, 5),
+		HealthyThreshold:   pointer.Int64Deref(spec.HealthyThreshold, 5),
+		UnhealthyThreshold: pointer.Int64Deref(spec.UnhealthyThreshold, 3),
+	}
+
+	if spec.Protocol != nil && *spec.Protocol == infrav1.HealthCheckProtocolHTTPS {
+		healthCheck.Type = string(infrav1.HealthCheckProtocolHTTPS)
+		healthCheck.HttpsHealthCheck = &compute.HTTPSHealthCheck{
+			Port:              int64(s.LoadBalancerBackendPort()),
 			PortSpecification: "USE_FIXED_PORT",
-		},
-		CheckIntervalSec:   10,
-		TimeoutSec:         5,
-		HealthyThreshold:   5,
-		UnhealthyThreshold: 3,
+			RequestPath:       pointer.StringDeref(spec.RequestPath, "/readyz"),
+		}
+	} else {
+		healthCheck.SslHealthCheck = &compute.SSLHealthCheck{
+			Port:              int64(s.LoadBalancerBackendPort()),
+			PortSpecification: "USE_FIXED_PORT",
+		}
 	}
+
+	if s.LoadBalancerType().IsRegional() {
+		healthCheck.Region = s.Region()
+	}
+
+	return healthCheck
 }
 
 // InstanceGroupSpec returns google compute instance-group spec.
 func (s *ClusterScope) InstanceGroupSpec(zone string) *compute.InstanceGroup {
-	port := pointer.Int32Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
-	return &compute.InstanceGroup{
-		Name: fmt.Sprintf("%s-%s-%s", s.Name(), infrav1.APIServerRoleTagValue, zone),
-		NamedPorts: []*compute.NamedPort{
-			{
-				Name: "apiserver",
-				Port: int64(port),
-			},
+	port := s.LoadBalancerBackendPort()
+	namedPorts := []*compute.NamedPort{
+		{
+			Name: "apiserver",
+			Port: int64(port),
 		},
 	}
+
+	for _, additional := range s.AdditionalPorts() {
+		namedPorts = append(namedPorts, &compute.NamedPort{
+			Name: additional.Name,
+			Port: int64(additional.Port),
+		})
+	}
+
+	return &compute.InstanceGroup{
+		Name:       fmt.Sprintf("%s-%s-%s", s.Name(), infrav1.APIServerRoleTagValue, zone),
+		NamedPorts: namedPorts,
+	}
 }
 
 // TargetTCPProxySpec returns google compute target-tcp-proxy spec.
@@ -317,11 +700,235 @@ func (s *ClusterScope) TargetTCPProxySpec() *compute.TargetTcpProxy {
 	}
 }
 
+// AdditionalPorts returns the ports of the control plane nodes exposed by the load balancer in addition to the
+// API server.
+func (s *ClusterScope) AdditionalPorts() []infrav1.LoadBalancerPort {
+	return s.GCPCluster.Spec.LoadBalancer.AdditionalPorts
+}
+
+// AdditionalPortHealthCheckSpec returns google compute health-check spec of an additional port.
+func (s *ClusterScope) AdditionalPortHealthCheckSpec(port infrav1.LoadBalancerPort) *compute.HealthCheck {
+	healthCheck := s.HealthCheckSpec()
+	healthCheck.Name = s.additionalPortName(port)
+	healthCheck.Type = "TCP"
+	healthCheck.SslHealthCheck = nil
+	healthCheck.HttpsHealthCheck = nil
+	healthCheck.TcpHealthCheck = &compute.TCPHealthCheck{
+		Port:              int64(port.Port),
+		PortSpecification: "USE_FIXED_PORT",
+	}
+	return healthCheck
+}
+
+// AdditionalPortBackendServiceSpec returns google compute backend-service spec of an additional port.
+func (s *ClusterScope) AdditionalPortBackendServiceSpec(port infrav1.LoadBalancerPort) *compute.BackendService {
+	backendService := s.BackendServiceSpec()
+	backendService.Name = s.additionalPortName(port)
+	if backendService.PortName != "" {
+		backendService.PortName = port.Name
+	}
+	return backendService
+}
+
+// AdditionalPortTargetTCPProxySpec returns google compute target-tcp-proxy spec of an additional port.
+func (s *ClusterScope) AdditionalPortTargetTCPProxySpec(port infrav1.LoadBalancerPort) *compute.TargetTcpProxy {
+	targetProxy := s.TargetTCPProxySpec()
+	targetProxy.Name = s.additionalPortName(port)
+	return targetProxy
+}
+
+// AdditionalPortForwardingRuleSpec returns google compute forwarding-rule spec of an additional port.
+func (s *ClusterScope) AdditionalPortForwardingRuleSpec(port infrav1.LoadBalancerPort) *compute.ForwardingRule {
+	forwardingRule := s.ForwardingRuleSpec()
+	forwardingRule.Name = s.additionalPortName(port)
+	if s.LoadBalancerType().IsRegional() {
+		forwardingRule.Ports = []string{strconv.FormatInt(int64(port.Port), 10)}
+	} else {
+		forwardingRule.PortRange = fmt.Sprintf("%d-%d", port.Port, port.Port)
+	}
+	return forwardingRule
+}
+
+// additionalPortName returns the name of the load balancer resources of an additional port.
+func (s *ClusterScope) additionalPortName(port infrav1.LoadBalancerPort) string {
+	return fmt.Sprintf("%s-%s", s.Name(), port.Name)
+}
+
+// loadBalancerBackendPorts returns the ports of the control plane nodes behind the load balancer.
+func (s *ClusterScope) loadBalancerBackendPorts() []string {
+	ports := []string{strconv.FormatInt(int64(s.LoadBalancerBackendPort()), 10)}
+	for _, port := range s.AdditionalPorts() {
+		ports = append(ports, strconv.FormatInt(int64(port.Port), 10))
+	}
+
+	return ports
+}
+
+// SecurityPolicyName returns the name of the security policy attached to the backend service of the API server,
+// or an empty string when there is none.
+func (s *ClusterScope) SecurityPolicyName() string {
+	policy := s.GCPCluster.Spec.LoadBalancer.SecurityPolicy
+	if policy == nil {
+		return ""
+	}
+
+	if policy.Name != nil {
+		return *policy.Name
+	}
+
+	return fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue)
+}
+
+// SecurityPolicySpec returns google compute security-policy spec of the allow-list of the API server,
+// or nil when the security policy is not defined inline.
+func (s *ClusterScope) SecurityPolicySpec() *computebeta.SecurityPolicy {
+	policy := s.GCPCluster.Spec.LoadBalancer.SecurityPolicy
+	if policy == nil || len(policy.AllowedSourceRanges) == 0 {
+		return nil
+	}
+
+	return &computebeta.SecurityPolicy{
+		Name:        s.SecurityPolicyName(),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Rules: []*computebeta.SecurityPolicyRule{
+			{
+				Action:      "allow",
+				Description: "Allow the source ranges of the cluster",
+				Priority:    0,
+				Match: &computebeta.SecurityPolicyRuleMatcher{
+					VersionedExpr: "SRC_IPS_V1",
+					Config: &computebeta.SecurityPolicyRuleMatcherConfig{
+						SrcIpRanges: policy.AllowedSourceRanges,
+					},
+				},
+			},
+			{
+				Action:      "deny(403)",
+				Description: "Default rule, deny all other sources",
+				Priority:    math.MaxInt32,
+				Match: &computebeta.SecurityPolicyRuleMatcher{
+					VersionedExpr: "SRC_IPS_V1",
+					Config: &computebeta.SecurityPolicyRuleMatcherConfig{
+						SrcIpRanges: []string{"*"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// IAPEndpointEnabled returns true if the cluster has an internal API server endpoint in addition to its external
+// load balancer.
+func (s *ClusterScope) IAPEndpointEnabled() bool {
+	iap := s.GCPCluster.Spec.IAP
+	return iap != nil && iap.InternalEndpoint && s.LoadBalancerType() != infrav1.Internal && s.LoadBalancerType() != infrav1.None
+}
+
+// IAPEndpointAddressSpec returns google compute address spec of the internal API server endpoint.
+func (s *ClusterScope) IAPEndpointAddressSpec() *compute.Address {
+	return &compute.Address{
+		Name:        s.iapEndpointName(),
+		AddressType: "INTERNAL",
+		Purpose:     "GCE_ENDPOINT",
+		Region:      s.Region(),
+		Subnetwork:  s.loadBalancerSubnetLink(),
+	}
+}
+
+// IAPEndpointBackendServiceSpec returns google compute regional backend-service spec of the internal API server
+// endpoint.
+func (s *ClusterScope) IAPEndpointBackendServiceSpec() *compute.BackendService {
+	return &compute.BackendService{
+		Name:                s.iapEndpointName(),
+		LoadBalancingScheme: "INTERNAL",
+		Protocol:            "TCP",
+		Region:              s.Region(),
+	}
+}
+
+// IAPEndpointForwardingRuleSpec returns google compute regional forwarding-rule spec of the internal API server
+// endpoint.
+func (s *ClusterScope) IAPEndpointForwardingRuleSpec() *compute.ForwardingRule {
+	return &compute.ForwardingRule{
+		Name:                s.iapEndpointName(),
+		IPProtocol:          "TCP",
+		LoadBalancingScheme: "INTERNAL",
+		Ports:               []string{strconv.FormatInt(int64(s.LoadBalancerBackendPort()), 10)},
+		Network:             s.NetworkLink(),
+		Subnetwork:          s.loadBalancerSubnetLink(),
+		Region:              s.Region(),
+	}
+}
+
+// IAPEndpointHealthCheckSpec returns google compute regional health-check spec of the internal API server endpoint.
+func (s *ClusterScope) IAPEndpointHealthCheckSpec() *compute.HealthCheck {
+	healthCheck := s.HealthCheckSpec()
+	healthCheck.Name = s.iapEndpointName()
+	healthCheck.Region = s.Region()
+	return healthCheck
+}
+
+// IAPEndpoint returns the observed state of the internal API server endpoint.
+func (s *ClusterScope) IAPEndpoint() *infrav1.IAPEndpointStatus {
+	return s.GCPCluster.Status.IAPEndpoint
+}
+
+// SetIAPEndpoint sets the observed state of the internal API server endpoint.
+func (s *ClusterScope) SetIAPEndpoint(status *infrav1.IAPEndpointStatus) {
+	s.GCPCluster.Status.IAPEndpoint = status
+}
+
+// iapEndpointName returns the name of the resources of the internal API server endpoint.
+func (s *ClusterScope) iapEndpointName() string {
+	return fmt.Sprintf("%s-%s-internal", s.Name(), infrav1.APIServerRoleTagValue)
+}
+
+// loadBalancerSubnetLink returns the partial URL for the subnetwork of the internal load balancer.
+func (s *ClusterScope) loadBalancerSubnetLink() string {
+	subnet := s.GCPCluster.Spec.LoadBalancer.Subnet
+	if subnet == nil {
+		for _, spec := range s.GCPCluster.Spec.Network.Subnets {
+			if spec.Region == "" || spec.Region == s.Region() {
+				subnet = pointer.String(spec.Name)
+				break
+			}
+		}
+	}
+
+	if subnet == nil {
+		// Networks in "auto" mode pick the subnetwork of the region.
+		return ""
+	}
+
+	return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.NetworkProject(), s.Region(), *subnet)
+}
+
 // ANCHOR_END: ClusterControlPlaneSpec
 
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject() error {
-	return s.patchHelper.Patch(context.TODO(), s.GCPCluster)
+	conditions.SetSummary(s.GCPCluster,
+		conditions.WithConditions(
+			infrav1.NetworkReadyCondition,
+			infrav1.FirewallRulesReadyCondition,
+			infrav1.LoadBalancerReadyCondition,
+			infrav1.FailureDomainsReadyCondition,
+			infrav1.BastionHostReadyCondition,
+		),
+		conditions.WithStepCounterIf(s.GCPCluster.ObjectMeta.DeletionTimestamp.IsZero()),
+	)
+
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.GCPCluster,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.NetworkReadyCondition,
+			infrav1.FirewallRulesReadyCondition,
+			infrav1.LoadBalancerReadyCondition,
+			infrav1.FailureDomainsReadyCondition,
+			infrav1.BastionHostReadyCondition,
+		}})
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// newComputeService returns a compute service authenticated with the identity referenced by the GCPCluster,
+// or with the controller credentials when the GCPCluster does not reference any identity.
+func newComputeService(ctx context.Context, c client.Client, gcpCluster *infrav1.GCPCluster) (*compute.Service, error) {
+	opts, err := identityClientOptions(ctx, c, gcpCluster.Spec.IdentityRef, gcpCluster.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return compute.NewService(ctx, opts...)
+}
+
+// identityClientOptions returns the client options holding the credentials of the identity referenced
+// from a cluster in the given namespace.
+func identityClientOptions(ctx context.Context, c client.Client, ref *infrav1.GCPIdentityReference, namespace string) ([]option.ClientOption, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	if c == nil {
+		return nil, errors.New("client is required to lookup the cluster identity")
+	}
+
+	identity := &infrav1.GCPClusterIdentity{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, identity); err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %q", infrav1.GCPClusterIdentityKind, ref.Name)
+	}
+
+	allowed, err := isNamespaceAllowed(ctx, c, identity.Spec.AllowedNamespaces, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, errors.Errorf("%s %q is not allowed to be used from namespace %q", infrav1.GCPClusterIdentityKind, ref.Name, namespace)
+	}
+
+	var opts []option.ClientOption
+	if secretRef := identity.Spec.SecretRef; secretRef != nil {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: secretRef.Namespace, Name: secretRef.Name}, secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to get secret %s/%s", secretRef.Namespace, secretRef.Name)
+		}
+
+		credentials, ok := secret.Data[infrav1.GCPClusterIdentitySecretKey]
+		if !ok {
+			return nil, errors.Errorf("secret %s/%s is missing the %q key", secretRef.Namespace, secretRef.Name, infrav1.GCPClusterIdentitySecretKey)
+		}
+
+		opts = append(opts, option.WithCredentialsJSON(credentials))
+	}
+
+	if identity.Spec.ImpersonateServiceAccount != nil {
+		ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+			TargetPrincipal: *identity.Spec.ImpersonateServiceAccount,
+			Scopes:          []string{compute.CloudPlatformScope},
+			Delegates:       identity.Spec.Delegates,
+		}, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to impersonate service account %q", *identity.Spec.ImpersonateServiceAccount)
+		}
+
+		opts = []option.ClientOption{option.WithTokenSource(ts)}
+	}
+
+	return opts, nil
+}
+
+// isNamespaceAllowed returns true if the namespace is allowed to use the identity.
+func isNamespaceAllowed(ctx context.Context, c client.Client, allowedNamespaces *infrav1.AllowedNamespaces, namespace string) (bool, error) {
+	if allowedNamespaces == nil {
+		return false, nil
+	}
+
+	// An empty allowedNamespaces object allows every namespace.
+	if len(allowedNamespaces.NamespaceList) == 0 && allowedNamespaces.Selector == nil {
+		return true, nil
+	}
+
+	for _, ns := range allowedNamespaces.NamespaceList {
+		if ns == namespace {
+			return true, nil
+		}
+	}
+
+	if allowedNamespaces.Selector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(allowedNamespaces.Selector)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse allowed namespaces selector")
+	}
+
+	// An empty selector selects no namespaces.
+	if selector.Empty() {
+		return false, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, errors.Wrapf(err, "failed to get namespace %q", namespace)
+	}
+
+	return selector.Matches(labels.Set(ns.GetLabels())), nil
+}
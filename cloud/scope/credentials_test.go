@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsNamespaceAllowed(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "team-a",
+				Labels: map[string]string{"team": "a"},
+			},
+		}).
+		Build()
+
+	tests := []struct {
+		name              string
+		allowedNamespaces *infrav1.AllowedNamespaces
+		namespace         string
+		want              bool
+	}{
+		{
+			name:              "nil allowedNamespaces (should deny)",
+			allowedNamespaces: nil,
+			namespace:         "team-a",
+			want:              false,
+		},
+		{
+			name:              "empty allowedNamespaces (should allow)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{},
+			namespace:         "team-a",
+			want:              true,
+		},
+		{
+			name:              "namespace in list (should allow)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{NamespaceList: []string{"team-b", "team-a"}},
+			namespace:         "team-a",
+			want:              true,
+		},
+		{
+			name:              "namespace not in list (should deny)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{NamespaceList: []string{"team-b"}},
+			namespace:         "team-a",
+			want:              false,
+		},
+		{
+			name: "namespace matching selector (should allow)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+			namespace: "team-a",
+			want:      true,
+		},
+		{
+			name: "namespace not matching selector (should deny)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+			},
+			namespace: "team-a",
+			want:      false,
+		},
+		{
+			name: "empty selector (should deny)",
+			allowedNamespaces: &infrav1.AllowedNamespaces{
+				Selector: &metav1.LabelSelector{},
+			},
+			namespace: "team-a",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isNamespaceAllowed(context.TODO(), fakec, tt.allowedNamespaces, tt.namespace)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("isNamespaceAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
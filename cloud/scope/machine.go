@@ -23,6 +23,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/mod/semver"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -133,6 +135,12 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsControlPlaneLoadBalanced returns true if the machine is a control plane registered into the instance group
+// of the API server load balancer.
+func (m *MachineScope) IsControlPlaneLoadBalanced() bool {
+	return m.IsControlPlane() && m.ClusterGetter.LoadBalancerType() != infrav1.None
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -142,6 +150,17 @@ func (m *MachineScope) Role() string {
 	return "node"
 }
 
+// IsSpot returns true if the machine is provisioned as a Spot VM.
+func (m *MachineScope) IsSpot() bool {
+	return m.GCPMachine.Spec.ProvisioningModel != nil && *m.GCPMachine.Spec.ProvisioningModel == infrav1.ProvisioningModelSpot
+}
+
+// IsPreemptible returns true if the instance can be reclaimed by GCE at any time,
+// either as a Spot VM or as a preemptible VM.
+func (m *MachineScope) IsPreemptible() bool {
+	return m.IsSpot() || m.GCPMachine.Spec.Preemptible
+}
+
 // GetInstanceID returns the GCPMachine instance id by parsing Spec.ProviderID.
 func (m *MachineScope) GetInstanceID() *string {
 	parsed, err := noderefutil.NewProviderID(m.GetProviderID())
@@ -186,6 +205,11 @@ func (m *MachineScope) SetReady() {
 	m.GCPMachine.Status.Ready = true
 }
 
+// SetNotReady sets the GCPMachine Ready Status to false.
+func (m *MachineScope) SetNotReady() {
+	m.GCPMachine.Status.Ready = false
+}
+
 // SetFailureMessage sets the GCPMachine status failure message.
 func (m *MachineScope) SetFailureMessage(v error) {
 	m.GCPMachine.Status.FailureMessage = pointer.StringPtr(v.Error())
@@ -209,6 +233,31 @@ func (m *MachineScope) SetAddresses(addressList []corev1.NodeAddress) {
 	m.GCPMachine.Status.Addresses = addressList
 }
 
+// SetAccelerators sets the accelerators field on the GCPMachine.
+func (m *MachineScope) SetAccelerators(accelerators []infrav1.Accelerator) {
+	m.GCPMachine.Status.Accelerators = accelerators
+}
+
+// SetShieldedInstanceConfig sets the effective Shielded VM configuration on the GCPMachine.
+func (m *MachineScope) SetShieldedInstanceConfig(config *infrav1.GCPShieldedInstanceConfig) {
+	m.GCPMachine.Status.ShieldedInstanceConfig = config
+}
+
+// SetConfidentialCompute sets the effective confidential compute configuration on the GCPMachine.
+func (m *MachineScope) SetConfidentialCompute(policy *infrav1.ConfidentialComputePolicy) {
+	m.GCPMachine.Status.ConfidentialCompute = policy
+}
+
+// MarkConditionTrue sets the condition of the GCPMachine to True.
+func (m *MachineScope) MarkConditionTrue(t clusterv1.ConditionType) {
+	conditions.MarkTrue(m.GCPMachine, t)
+}
+
+// MarkConditionFalse sets the condition of the GCPMachine to False with the given reason and message.
+func (m *MachineScope) MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(m.GCPMachine, t, reason, severity, "%s", message)
+}
+
 // ANCHOR_END: MachineSetter
 
 // ANCHOR: MachineInstanceSpec
@@ -270,10 +319,105 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 	return additionalDisks
 }
 
+// InstanceDiskEncryptionKeysSpec returns the encryption keys of the instance disks, in the order of the instance
+// disks: the root disk first, then the additional disks. Customer-supplied keys are read from their Secret.
+func (m *MachineScope) InstanceDiskEncryptionKeysSpec() ([]*compute.CustomerEncryptionKey, error) {
+	keys := make([]*compute.CustomerEncryptionKey, 0, len(m.GCPMachine.Spec.AdditionalDisks)+1)
+	rootKey, err := m.diskEncryptionKey(m.GCPMachine.Spec.RootDiskEncryptionKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get root disk encryption key")
+	}
+	keys = append(keys, rootKey)
+
+	for i, disk := range m.GCPMachine.Spec.AdditionalDisks {
+		key, err := m.diskEncryptionKey(disk.EncryptionKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get encryption key of additional disk %d", i)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// diskEncryptionKey returns the compute encryption key matching the given customer encryption key.
+func (m *MachineScope) diskEncryptionKey(key *infrav1.CustomerEncryptionKey) (*compute.CustomerEncryptionKey, error) {
+	if key == nil {
+		return nil, nil
+	}
+
+	switch key.KeyType {
+	case infrav1.CustomerManagedKey:
+		if key.ManagedKey == nil {
+			return nil, errors.New("managedKey is required for the Managed key type")
+		}
+
+		return &compute.CustomerEncryptionKey{
+			KmsKeyName:           key.ManagedKey.KMSKeyName,
+			KmsKeyServiceAccount: pointer.StringDeref(key.KMSKeyServiceAccount, ""),
+		}, nil
+	case infrav1.CustomerSuppliedKey:
+		if key.SuppliedKey == nil {
+			return nil, errors.New("suppliedKey is required for the Supplied key type")
+		}
+
+		if ref := key.SuppliedKey.RawKey; ref != nil {
+			rawKey, err := m.getSecretValue(ref)
+			if err != nil {
+				return nil, err
+			}
+
+			return &compute.CustomerEncryptionKey{RawKey: rawKey}, nil
+		}
+
+		if ref := key.SuppliedKey.RSAEncryptedKey; ref != nil {
+			rsaEncryptedKey, err := m.getSecretValue(ref)
+			if err != nil {
+				return nil, err
+			}
+
+			return &compute.CustomerEncryptionKey{RsaEncryptedKey: rsaEncryptedKey}, nil
+		}
+
+		return nil, errors.New("either rawKey or rsaEncryptedKey is required for the Supplied key type")
+	default:
+		return nil, errors.Errorf("unknown key type %q", key.KeyType)
+	}
+}
+
+// getSecretValue returns the value of the key of a Secret in the GCPMachine namespace.
+func (m *MachineScope) getSecretValue(ref *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: ref.Name}
+	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve secret %s/%s", m.Namespace(), ref.Name)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("secret %s/%s is missing the %q key", m.Namespace(), ref.Name, ref.Key)
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+// InstanceGuestAcceleratorsSpec returns compute instance guest accelerators spec.
+func (m *MachineScope) InstanceGuestAcceleratorsSpec() []*compute.AcceleratorConfig {
+	var accelerators []*compute.AcceleratorConfig
+	for _, accelerator := range m.GCPMachine.Spec.GuestAccelerators {
+		accelerators = append(accelerators, &compute.AcceleratorConfig{
+			AcceleratorType:  path.Join("zones", m.Zone(), "acceleratorTypes", accelerator.Type),
+			AcceleratorCount: accelerator.Count,
+		})
+	}
+
+	return accelerators
+}
+
 // InstanceNetworkInterfaceSpec returns compute network interface spec.
 func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface {
 	networkInterface := &compute.NetworkInterface{
-		Network: path.Join("projects", m.ClusterGetter.Project(), "global", "networks", m.ClusterGetter.NetworkName()),
+		Network: path.Join("projects", m.ClusterGetter.NetworkProject(), "global", "networks", m.ClusterGetter.NetworkName()),
 	}
 
 	if m.GCPMachine.Spec.PublicIP != nil && *m.GCPMachine.Spec.PublicIP {
@@ -286,7 +430,7 @@ func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface
 	}
 
 	if m.GCPMachine.Spec.Subnet != nil {
-		networkInterface.Subnetwork = path.Join("regions", m.ClusterGetter.Region(), "subnetworks", *m.GCPMachine.Spec.Subnet)
+		networkInterface.Subnetwork = path.Join("projects", m.ClusterGetter.NetworkProject(), "regions", m.ClusterGetter.Region(), "subnetworks", *m.GCPMachine.Spec.Subnet)
 	}
 
 	return networkInterface
@@ -347,6 +491,45 @@ func (m *MachineScope) InstanceSpec() *compute.Instance {
 		},
 	}
 
+	if m.IsSpot() {
+		instance.Scheduling.ProvisioningModel = "SPOT"
+		instance.Scheduling.AutomaticRestart = pointer.Bool(false)
+		instance.Scheduling.OnHostMaintenance = "TERMINATE"
+		instance.Scheduling.InstanceTerminationAction = "STOP"
+		if m.GCPMachine.Spec.InstanceTerminationAction != nil {
+			instance.Scheduling.InstanceTerminationAction = strings.ToUpper(string(*m.GCPMachine.Spec.InstanceTerminationAction))
+		}
+		if d := m.GCPMachine.Spec.MaxRunDuration; d != nil {
+			instance.Scheduling.MaxRunDuration = &compute.Duration{
+				Seconds: int64(d.Duration / time.Second),
+				Nanos:   int64(d.Duration % time.Second),
+			}
+		}
+	}
+
+	instance.GuestAccelerators = m.InstanceGuestAcceleratorsSpec()
+	if len(instance.GuestAccelerators) > 0 {
+		// Instances with accelerators can not be live migrated.
+		instance.Scheduling.OnHostMaintenance = "TERMINATE"
+	}
+
+	if config := m.GCPMachine.Spec.ShieldedInstanceConfig; config != nil {
+		instance.ShieldedInstanceConfig = &compute.ShieldedInstanceConfig{
+			EnableSecureBoot:          config.SecureBoot == infrav1.SecureBootPolicyEnabled,
+			EnableVtpm:                config.VirtualizedTrustedPlatformModule != infrav1.VirtualizedTrustedPlatformModulePolicyDisabled,
+			EnableIntegrityMonitoring: config.IntegrityMonitoring != infrav1.IntegrityMonitoringPolicyDisabled,
+			ForceSendFields:           []string{"EnableSecureBoot", "EnableVtpm", "EnableIntegrityMonitoring"},
+		}
+	}
+
+	if m.GCPMachine.Spec.ConfidentialCompute != nil && *m.GCPMachine.Spec.ConfidentialCompute == infrav1.ConfidentialComputePolicyEnabled {
+		instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
+			EnableConfidentialCompute: true,
+		}
+		// Confidential VMs can not be live migrated.
+		instance.Scheduling.OnHostMaintenance = "TERMINATE"
+	}
+
 	instance.CanIpForward = true
 	if m.GCPMachine.Spec.IPForwarding != nil && *m.GCPMachine.Spec.IPForwarding == infrav1.IPForwardingDisabled {
 		instance.CanIpForward = false
@@ -384,7 +567,24 @@ func (m *MachineScope) GetBootstrapData() (string, error) {
 
 // PatchObject persists the cluster configuration and status.
 func (m *MachineScope) PatchObject() error {
-	return m.patchHelper.Patch(context.TODO(), m.GCPMachine)
+	conditions.SetSummary(m.GCPMachine,
+		conditions.WithConditions(
+			infrav1.BootstrapDataReadyCondition,
+			infrav1.InstanceReadyCondition,
+			infrav1.ControlPlaneLBRegisteredCondition,
+		),
+		conditions.WithStepCounterIf(m.GCPMachine.ObjectMeta.DeletionTimestamp.IsZero()),
+	)
+
+	return m.patchHelper.Patch(
+		context.TODO(),
+		m.GCPMachine,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.BootstrapDataReadyCondition,
+			infrav1.InstanceReadyCondition,
+			infrav1.ControlPlaneLBRegisteredCondition,
+		}})
 }
 
 // Close closes the current scope persisting the cluster configuration and status.
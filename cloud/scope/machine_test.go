@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineScope_InstanceSpecScheduling(t *testing.T) {
+	_ = infrav1.AddToScheme(scheme.Scheme)
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+
+	spot := infrav1.ProvisioningModelSpot
+	terminationActionDelete := infrav1.InstanceTerminationActionDelete
+	tests := []struct {
+		name string
+		spec infrav1.GCPMachineSpec
+		want *compute.Scheduling
+	}{
+		{
+			name: "standard instance",
+			want: &compute.Scheduling{},
+		},
+		{
+			name: "spot instance with a max run duration",
+			spec: infrav1.GCPMachineSpec{
+				ProvisioningModel:         &spot,
+				InstanceTerminationAction: &terminationActionDelete,
+				MaxRunDuration:            &metav1.Duration{Duration: 90*time.Minute + 500*time.Millisecond},
+			},
+			want: &compute.Scheduling{
+				ProvisioningModel:         "SPOT",
+				AutomaticRestart:          pointer.Bool(false),
+				OnHostMaintenance:         "TERMINATE",
+				InstanceTerminationAction: "DELETE",
+				MaxRunDuration:            &compute.Duration{Seconds: 5400, Nanos: 500000000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakec := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			clusterScope, err := NewClusterScope(ClusterScopeParams{
+				GCPServices: GCPServices{Compute: &compute.Service{}},
+				Client:      fakec,
+				Cluster: &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+				},
+				GCPCluster: &infrav1.GCPCluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Namespace: "default"},
+					Spec:       infrav1.GCPClusterSpec{Project: "my-proj", Region: "us-central1"},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			machineScope, err := NewMachineScope(MachineScopeParams{
+				Client:        fakec,
+				ClusterGetter: clusterScope,
+				Machine: &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"},
+					Spec:       clusterv1.MachineSpec{FailureDomain: pointer.String("us-central1-c")},
+				},
+				GCPMachine: &infrav1.GCPMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-machine", Namespace: "default"},
+					Spec:       tt.spec,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d := cmp.Diff(tt.want, machineScope.InstanceSpec().Scheduling); d != "" {
+				t.Errorf("MachineScope.InstanceSpec() scheduling mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxInstanceGroupNameLength is the maximum length of the machine pool name used in the name of its managed
+// instance group, so that the name followed by the hash of the pool and by the template hashes fits in the
+// 63 characters of a GCE name.
+const maxInstanceGroupNameLength = 37
+
+// MachinePoolScopeParams defines the input parameters used to create a new MachinePoolScope.
+type MachinePoolScopeParams struct {
+	Client         client.Client
+	ClusterGetter  cloud.ClusterGetter
+	Cluster        *clusterv1.Cluster
+	MachinePool    *expclusterv1.MachinePool
+	GCPMachinePool *infrav1.GCPMachinePool
+}
+
+// NewMachinePoolScope creates a new MachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachinePoolScope")
+	}
+	if params.Cluster == nil {
+		return nil, errors.New("cluster is required when creating a MachinePoolScope")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("machine pool is required when creating a MachinePoolScope")
+	}
+	if params.GCPMachinePool == nil {
+		return nil, errors.New("gcp machine pool is required when creating a MachinePoolScope")
+	}
+
+	helper, err := patch.NewHelper(params.GCPMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &MachinePoolScope{
+		client:         params.Client,
+		Cluster:        params.Cluster,
+		MachinePool:    params.MachinePool,
+		GCPMachinePool: params.GCPMachinePool,
+		ClusterGetter:  params.ClusterGetter,
+		patchHelper:    helper,
+	}, nil
+}
+
+// MachinePoolScope defines a scope defined around a machine pool and its cluster.
+type MachinePoolScope struct {
+	client         client.Client
+	patchHelper    *patch.Helper
+	ClusterGetter  cloud.ClusterGetter
+	Cluster        *clusterv1.Cluster
+	MachinePool    *expclusterv1.MachinePool
+	GCPMachinePool *infrav1.GCPMachinePool
+}
+
+// ANCHOR: MachinePoolGetter
+
+// Cloud returns initialized cloud.
+func (m *MachinePoolScope) Cloud() cloud.Cloud {
+	return m.ClusterGetter.Cloud()
+}
+
+// ComputeService returns the compute service used for the instance templates and managed instance groups.
+func (m *MachinePoolScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// Name returns the GCPMachinePool name.
+func (m *MachinePoolScope) Name() string {
+	return m.GCPMachinePool.Name
+}
+
+// Namespace returns the namespace name.
+func (m *MachinePoolScope) Namespace() string {
+	return m.GCPMachinePool.Namespace
+}
+
+// Project return the project for the GCPMachinePool's cluster.
+func (m *MachinePoolScope) Project() string {
+	return m.ClusterGetter.Project()
+}
+
+// Region returns the region of the managed instance group.
+func (m *MachinePoolScope) Region() string {
+	return m.ClusterGetter.Region()
+}
+
+// Zones returns the zones the instances of the pool are distributed in: the failure domains of the
+// MachinePool if any, otherwise all the failure domains of the cluster.
+func (m *MachinePoolScope) Zones() []string {
+	if len(m.MachinePool.Spec.FailureDomains) > 0 {
+		zones := append([]string{}, m.MachinePool.Spec.FailureDomains...)
+		sort.Strings(zones)
+		return zones
+	}
+
+	fd := m.ClusterGetter.FailureDomains()
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// Replicas returns the desired number of instances of the pool.
+func (m *MachinePoolScope) Replicas() int64 {
+	return int64(pointer.Int32Deref(m.MachinePool.Spec.Replicas, 1))
+}
+
+// GetBootstrapData returns the bootstrap data from the secret in the MachinePool's bootstrap.dataSecretName.
+func (m *MachinePoolScope) GetBootstrapData() (string, error) {
+	if m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
+		return "", errors.New("error retrieving bootstrap data: linked MachinePool's bootstrap.dataSecretName is nil")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName}
+	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for GCPMachinePool %s/%s", m.Namespace(), m.Name())
+	}
+
+	value, ok := secret.Data["value"]
+	if !ok {
+		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
+	}
+
+	return string(value), nil
+}
+
+// ANCHOR_END: MachinePoolGetter
+
+// ANCHOR: MachinePoolSetter
+
+// SetReady sets the GCPMachinePool Ready Status.
+func (m *MachinePoolScope) SetReady() {
+	m.GCPMachinePool.Status.Ready = true
+}
+
+// SetNotReady sets the GCPMachinePool Ready Status to false.
+func (m *MachinePoolScope) SetNotReady() {
+	m.GCPMachinePool.Status.Ready = false
+}
+
+// SetReplicas sets the number of running instances of the GCPMachinePool.
+func (m *MachinePoolScope) SetReplicas(replicas int32) {
+	m.GCPMachinePool.Status.Replicas = replicas
+}
+
+// SetProviderIDList sets the provider IDs of the instances of the GCPMachinePool.
+func (m *MachinePoolScope) SetProviderIDList(providerIDs []string) {
+	m.GCPMachinePool.Spec.ProviderIDList = providerIDs
+}
+
+// SetInstanceTemplate sets the name of the instance template used by the GCPMachinePool.
+func (m *MachinePoolScope) SetInstanceTemplate(name string) {
+	m.GCPMachinePool.Status.InstanceTemplate = name
+}
+
+// MarkConditionTrue sets the condition of the GCPMachinePool to True.
+func (m *MachinePoolScope) MarkConditionTrue(t clusterv1.ConditionType) {
+	conditions.MarkTrue(m.GCPMachinePool, t)
+}
+
+// MarkConditionFalse sets the condition of the GCPMachinePool to False with the given reason and message.
+func (m *MachinePoolScope) MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(m.GCPMachinePool, t, reason, severity, "%s", message)
+}
+
+// ANCHOR_END: MachinePoolSetter
+
+// ANCHOR: MachinePoolInstanceTemplateSpec
+
+// InstanceTemplateSpec returns the instance template of the pool. The instances are built with the same logic
+// as the instances of standalone GCPMachines. Instance templates are immutable, so the name of the template
+// ends with a hash of its properties without the bootstrap data, followed by a hash of the bootstrap data:
+// a change of the template spec or of the bootstrap data results in a new instance template, and the two
+// changes can be told apart from the names of the templates.
+func (m *MachinePoolScope) InstanceTemplateSpec(bootstrapData string) (*compute.InstanceTemplate, error) {
+	machineScope := &MachineScope{
+		client:        m.client,
+		ClusterGetter: m.ClusterGetter,
+		Machine: &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				Version: m.MachinePool.Spec.Template.Spec.Version,
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.Name(),
+				Namespace: m.Namespace(),
+			},
+			Spec: *m.GCPMachinePool.Spec.Template.DeepCopy(),
+		},
+	}
+
+	instance := machineScope.InstanceSpec()
+	encryptionKeys, err := machineScope.InstanceDiskEncryptionKeysSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range encryptionKeys {
+		instance.Disks[i].DiskEncryptionKey = key
+	}
+
+	// Instance templates reference the machine, disk and accelerator types by name instead of zonal URLs.
+	for _, disk := range instance.Disks {
+		disk.InitializeParams.DiskType = path.Base(disk.InitializeParams.DiskType)
+	}
+	for _, accelerator := range instance.GuestAccelerators {
+		accelerator.AcceleratorType = path.Base(accelerator.AcceleratorType)
+	}
+
+	properties := &compute.InstanceProperties{
+		CanIpForward:               instance.CanIpForward,
+		ConfidentialInstanceConfig: instance.ConfidentialInstanceConfig,
+		Disks:                      instance.Disks,
+		GuestAccelerators:          instance.GuestAccelerators,
+		Labels:                     instance.Labels,
+		MachineType:                m.GCPMachinePool.Spec.Template.InstanceType,
+		Metadata:                   instance.Metadata,
+		NetworkInterfaces:          instance.NetworkInterfaces,
+		Scheduling:                 instance.Scheduling,
+		ServiceAccounts:            instance.ServiceAccounts,
+		ShieldedInstanceConfig:     instance.ShieldedInstanceConfig,
+		Tags:                       instance.Tags,
+	}
+
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal instance template properties")
+	}
+
+	specHash := fmt.Sprintf("%x", sha256.Sum256(data))
+	dataHash := fmt.Sprintf("%x", sha256.Sum256([]byte(bootstrapData)))
+
+	properties.Metadata.Items = append(properties.Metadata.Items, &compute.MetadataItems{
+		Key:   "user-data",
+		Value: pointer.String(bootstrapData),
+	})
+
+	return &compute.InstanceTemplate{
+		Name:        m.InstanceTemplatePrefix() + specHash[:8] + dataHash[:8],
+		Description: m.InstanceTemplateDescription(),
+		Properties:  properties,
+	}, nil
+}
+
+// InstanceGroupName returns the name of the managed instance group of the pool. The name of the GCPMachinePool
+// is followed by a hash of its namespace and name, so pools with the same name in other namespaces, or sharing
+// the beginning of a long name, don't share their managed instance group.
+func (m *MachinePoolScope) InstanceGroupName() string {
+	name := m.Name()
+	if len(name) > maxInstanceGroupNameLength {
+		name = name[:maxInstanceGroupNameLength]
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(m.Namespace()+"/"+m.Name())))
+	return fmt.Sprintf("%s-%s", name, hash[:8])
+}
+
+// InstanceTemplatePrefix returns the prefix of the names of the instance templates of the pool.
+func (m *MachinePoolScope) InstanceTemplatePrefix() string {
+	return m.InstanceGroupName() + "-"
+}
+
+// InstanceTemplateDescription returns the description of the instance templates of the pool, marking them as
+// owned by its cluster.
+func (m *MachinePoolScope) InstanceTemplateDescription() string {
+	return infrav1.ClusterTagKey(m.ClusterGetter.Name())
+}
+
+// ANCHOR_END: MachinePoolInstanceTemplateSpec
+
+// PatchObject persists the machine pool spec and status.
+func (m *MachinePoolScope) PatchObject() error {
+	conditions.SetSummary(m.GCPMachinePool,
+		conditions.WithConditions(
+			infrav1.BootstrapDataReadyCondition,
+			infrav1.InstanceTemplateReadyCondition,
+			infrav1.InstanceGroupReadyCondition,
+		),
+	)
+
+	return m.patchHelper.Patch(
+		context.TODO(),
+		m.GCPMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.BootstrapDataReadyCondition,
+			infrav1.InstanceTemplateReadyCondition,
+			infrav1.InstanceGroupReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the machine pool configuration and status.
+func (m *MachinePoolScope) Close() error {
+	return m.PatchObject()
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedClusterScopeParams defines the input parameters used to create a new ManagedClusterScope.
+type ManagedClusterScopeParams struct {
+	GCPServices
+	Client            client.Client
+	Cluster           *clusterv1.Cluster
+	GCPManagedCluster *infrav1.GCPManagedCluster
+}
+
+// NewManagedClusterScope creates a new ManagedClusterScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewManagedClusterScope(params ManagedClusterScopeParams) (*ManagedClusterScope, error) {
+	if params.Cluster == nil {
+		return nil, errors.New("failed to generate new scope from nil Cluster")
+	}
+	if params.GCPManagedCluster == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedCluster")
+	}
+
+	if params.GCPServices.Compute == nil {
+		opts, err := identityClientOptions(context.TODO(), params.Client, params.GCPManagedCluster.Spec.IdentityRef, params.GCPManagedCluster.Namespace)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
+		}
+
+		computeSvc, err := compute.NewService(context.TODO(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
+		}
+
+		params.GCPServices.Compute = computeSvc
+	}
+
+	helper, err := patch.NewHelper(params.GCPManagedCluster, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ManagedClusterScope{
+		client:            params.Client,
+		Cluster:           params.Cluster,
+		GCPManagedCluster: params.GCPManagedCluster,
+		GCPServices:       params.GCPServices,
+		patchHelper:       helper,
+	}, nil
+}
+
+// ManagedClusterScope defines the basic context for an actuator to operate upon a GKE cluster infrastructure.
+type ManagedClusterScope struct {
+	client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster           *clusterv1.Cluster
+	GCPManagedCluster *infrav1.GCPManagedCluster
+	GCPServices
+}
+
+// ANCHOR: ManagedClusterGetter
+
+// Cloud returns initialized cloud.
+func (s *ManagedClusterScope) Cloud() cloud.Cloud {
+	return newCloud(s.Project(), s.GCPServices)
+}
+
+// NetworkCloud returns initialized cloud for the project hosting the network.
+func (s *ManagedClusterScope) NetworkCloud() cloud.Cloud {
+	return newCloud(s.NetworkProject(), s.GCPServices)
+}
+
+// ComputeService returns the compute service used for the resources not supported by cloud.Cloud.
+func (s *ManagedClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
+// Project returns the current project name.
+func (s *ManagedClusterScope) Project() string {
+	return s.GCPManagedCluster.Spec.Project
+}
+
+// Region returns the cluster region.
+func (s *ManagedClusterScope) Region() string {
+	return s.GCPManagedCluster.Spec.Region
+}
+
+// Name returns the cluster name.
+func (s *ManagedClusterScope) Name() string {
+	return s.Cluster.Name
+}
+
+// Namespace returns the cluster namespace.
+func (s *ManagedClusterScope) Namespace() string {
+	return s.Cluster.Namespace
+}
+
+// NetworkName returns the cluster network unique identifier.
+func (s *ManagedClusterScope) NetworkName() string {
+	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.Name, "default")
+}
+
+// NetworkProject returns the project name where the network resides.
+func (s *ManagedClusterScope) NetworkProject() string {
+	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.HostProject, s.Project())
+}
+
+// IsSharedVpc returns true if the network resides in a host project other than the cluster project.
+func (s *ManagedClusterScope) IsSharedVpc() bool {
+	return s.NetworkProject() != s.Project()
+}
+
+// NetworkLink returns the partial URL for the network.
+func (s *ManagedClusterScope) NetworkLink() string {
+	return fmt.Sprintf("projects/%s/global/networks/%s", s.NetworkProject(), s.NetworkName())
+}
+
+// Network returns the cluster network object.
+func (s *ManagedClusterScope) Network() *infrav1.Network {
+	return &s.GCPManagedCluster.Status.Network
+}
+
+// AdditionalLabels returns the cluster additional labels.
+func (s *ManagedClusterScope) AdditionalLabels() infrav1.Labels {
+	return s.GCPManagedCluster.Spec.AdditionalLabels
+}
+
+// FailureDomains returns the cluster failure domains. GKE places the nodes of the cluster,
+// a GKE cluster has no failure domains.
+func (s *ManagedClusterScope) FailureDomains() clusterv1.FailureDomains {
+	return nil
+}
+
+// ControlPlaneEndpoint returns the cluster control-plane endpoint.
+func (s *ManagedClusterScope) ControlPlaneEndpoint() clusterv1.APIEndpoint {
+	return s.GCPManagedCluster.Spec.ControlPlaneEndpoint
+}
+
+// LoadBalancerType returns the type of load balancer created for the API server. GKE clusters expose their own
+// endpoint, no load balancer is created for them.
+func (s *ManagedClusterScope) LoadBalancerType() infrav1.LoadBalancerType {
+	return infrav1.None
+}
+
+// ANCHOR_END: ManagedClusterGetter
+
+// ANCHOR: ManagedClusterSetter
+
+// SetReady sets cluster ready status.
+func (s *ManagedClusterScope) SetReady() {
+	s.GCPManagedCluster.Status.Ready = true
+}
+
+// SetControlPlaneEndpoint sets cluster control-plane endpoint.
+func (s *ManagedClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint) {
+	s.GCPManagedCluster.Spec.ControlPlaneEndpoint = endpoint
+}
+
+// ANCHOR_END: ManagedClusterSetter
+
+// ANCHOR: ManagedClusterNetworkSpec
+
+// NetworkSpec returns google compute network spec.
+func (s *ManagedClusterScope) NetworkSpec() *compute.Network {
+	return networkSpec(s.Name(), s.NetworkName(), s.GCPManagedCluster.Spec.Network)
+}
+
+// SubnetSpecs returns google compute subnets spec.
+func (s *ManagedClusterScope) SubnetSpecs() []*compute.Subnetwork {
+	return subnetSpecs(s.Name(), s.Region(), s.GCPManagedCluster.Spec.Network)
+}
+
+// NatRouterSpec returns google compute nat router spec.
+func (s *ManagedClusterScope) NatRouterSpec() *compute.Router {
+	return natRouterSpec(s.NetworkName())
+}
+
+// ANCHOR_END: ManagedClusterNetworkSpec
+
+// PatchObject persists the managed cluster configuration and status.
+func (s *ManagedClusterScope) PatchObject() error {
+	conditions.SetSummary(s.GCPManagedCluster,
+		conditions.WithConditions(
+			infrav1.NetworkReadyCondition,
+		),
+	)
+
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.GCPManagedCluster,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.NetworkReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the managed cluster configuration and status.
+func (s *ManagedClusterScope) Close() error {
+	return s.PatchObject()
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/option"
+	"google.golang.org/api/transport"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	exputil "sigs.k8s.io/cluster-api/exp/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedControlPlaneScopeParams defines the input parameters used to create a new ManagedControlPlaneScope.
+type ManagedControlPlaneScopeParams struct {
+	GCPServices
+	Client                 client.Client
+	Cluster                *clusterv1.Cluster
+	GCPManagedCluster      *infrav1.GCPManagedCluster
+	GCPManagedControlPlane *infrav1.GCPManagedControlPlane
+}
+
+// NewManagedControlPlaneScope creates a new ManagedControlPlaneScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewManagedControlPlaneScope(params ManagedControlPlaneScopeParams) (*ManagedControlPlaneScope, error) {
+	if params.Cluster == nil {
+		return nil, errors.New("failed to generate new scope from nil Cluster")
+	}
+	if params.GCPManagedCluster == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedCluster")
+	}
+	if params.GCPManagedControlPlane == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedControlPlane")
+	}
+
+	opts, err := identityClientOptions(context.TODO(), params.Client, params.GCPManagedCluster.Spec.IdentityRef, params.GCPManagedCluster.Namespace)
+	if err != nil {
+		return nil, errors.Errorf("failed to create gcp container client: %v", err)
+	}
+
+	if params.GCPServices.Container == nil {
+		containerSvc, err := container.NewService(context.TODO(), opts...)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp container client: %v", err)
+		}
+
+		params.GCPServices.Container = containerSvc
+	}
+
+	helper, err := patch.NewHelper(params.GCPManagedControlPlane, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ManagedControlPlaneScope{
+		client:                 params.Client,
+		credentialsOptions:     opts,
+		Cluster:                params.Cluster,
+		GCPManagedCluster:      params.GCPManagedCluster,
+		GCPManagedControlPlane: params.GCPManagedControlPlane,
+		GCPServices:            params.GCPServices,
+		patchHelper:            helper,
+	}, nil
+}
+
+// ManagedControlPlaneScope defines the basic context for an actuator to operate upon a GKE control plane.
+type ManagedControlPlaneScope struct {
+	client             client.Client
+	patchHelper        *patch.Helper
+	credentialsOptions []option.ClientOption
+
+	Cluster                *clusterv1.Cluster
+	GCPManagedCluster      *infrav1.GCPManagedCluster
+	GCPManagedControlPlane *infrav1.GCPManagedControlPlane
+	GCPServices
+}
+
+// ANCHOR: ManagedControlPlaneGetter
+
+// Client returns the client of the management cluster.
+func (s *ManagedControlPlaneScope) Client() client.Client {
+	return s.client
+}
+
+// ContainerService returns the container service used to manage the GKE cluster.
+func (s *ManagedControlPlaneScope) ContainerService() *container.Service {
+	return s.GCPServices.Container
+}
+
+// Name returns the cluster name.
+func (s *ManagedControlPlaneScope) Name() string {
+	return s.Cluster.Name
+}
+
+// Namespace returns the cluster namespace.
+func (s *ManagedControlPlaneScope) Namespace() string {
+	return s.Cluster.Namespace
+}
+
+// Project returns the project of the GKE cluster.
+func (s *ManagedControlPlaneScope) Project() string {
+	return s.GCPManagedCluster.Spec.Project
+}
+
+// Region returns the region of the GKE cluster.
+func (s *ManagedControlPlaneScope) Region() string {
+	return s.GCPManagedCluster.Spec.Region
+}
+
+// ClusterName returns the name of the GKE cluster.
+func (s *ManagedControlPlaneScope) ClusterName() string {
+	return gkeClusterName(s.Cluster, s.GCPManagedControlPlane)
+}
+
+// ClusterLocation returns the location of the GKE cluster, in the format `projects/*/locations/*`.
+func (s *ManagedControlPlaneScope) ClusterLocation() string {
+	return fmt.Sprintf("projects/%s/locations/%s", s.Project(), s.Region())
+}
+
+// ClusterFullName returns the name of the GKE cluster, in the format `projects/*/locations/*/clusters/*`.
+func (s *ManagedControlPlaneScope) ClusterFullName() string {
+	return fmt.Sprintf("%s/clusters/%s", s.ClusterLocation(), s.ClusterName())
+}
+
+// DesiredVersion returns the Kubernetes version requested for the control plane, without the leading v.
+func (s *ManagedControlPlaneScope) DesiredVersion() *string {
+	if s.GCPManagedControlPlane.Spec.ControlPlaneVersion == nil {
+		return nil
+	}
+
+	return pointer.String(strings.TrimPrefix(*s.GCPManagedControlPlane.Spec.ControlPlaneVersion, "v"))
+}
+
+// DesiredReleaseChannel returns the GKE release channel requested for the cluster, or an empty string
+// when the release channel is not managed.
+func (s *ManagedControlPlaneScope) DesiredReleaseChannel() string {
+	if s.GCPManagedControlPlane.Spec.ReleaseChannel == nil {
+		return ""
+	}
+
+	return strings.ToUpper(string(*s.GCPManagedControlPlane.Spec.ReleaseChannel))
+}
+
+// KubeconfigToken returns a new access token of the credentials used to manage the GKE cluster
+// and its expiry. The token authenticates the kubeconfig of the cluster.
+func (s *ManagedControlPlaneScope) KubeconfigToken(ctx context.Context) (string, time.Time, error) {
+	opts := append([]option.ClientOption{option.WithScopes(container.CloudPlatformScope)}, s.credentialsOptions...)
+	creds, err := transport.Creds(ctx, opts...)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to get credentials")
+	}
+
+	token, err := creds.TokenSource.Token()
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "failed to get access token")
+	}
+
+	return token.AccessToken, token.Expiry, nil
+}
+
+// KubeconfigOwnerRef returns the owner reference of the kubeconfig secret.
+func (s *ManagedControlPlaneScope) KubeconfigOwnerRef() metav1.OwnerReference {
+	return *metav1.NewControllerRef(s.GCPManagedControlPlane, infrav1.GroupVersion.WithKind("GCPManagedControlPlane"))
+}
+
+// ANCHOR_END: ManagedControlPlaneGetter
+
+// ANCHOR: ManagedControlPlaneSetter
+
+// SetEndpoint sets the control plane endpoint to the address of the GKE cluster.
+func (s *ManagedControlPlaneScope) SetEndpoint(host string) {
+	s.GCPManagedControlPlane.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
+		Host: host,
+		Port: 443,
+	}
+}
+
+// SetReady sets the GCPManagedControlPlane Ready Status.
+func (s *ManagedControlPlaneScope) SetReady() {
+	s.GCPManagedControlPlane.Status.Ready = true
+}
+
+// SetNotReady sets the GCPManagedControlPlane Ready Status to false.
+func (s *ManagedControlPlaneScope) SetNotReady() {
+	s.GCPManagedControlPlane.Status.Ready = false
+}
+
+// SetInitialized sets the GCPManagedControlPlane Initialized Status.
+func (s *ManagedControlPlaneScope) SetInitialized() {
+	s.GCPManagedControlPlane.Status.Initialized = true
+}
+
+// SetCurrentVersion sets the Kubernetes version of the control plane reported by GKE.
+func (s *ManagedControlPlaneScope) SetCurrentVersion(version string) {
+	s.GCPManagedControlPlane.Status.CurrentVersion = version
+}
+
+// MarkConditionTrue sets the condition of the GCPManagedControlPlane to True.
+func (s *ManagedControlPlaneScope) MarkConditionTrue(t clusterv1.ConditionType) {
+	conditions.MarkTrue(s.GCPManagedControlPlane, t)
+}
+
+// MarkConditionFalse sets the condition of the GCPManagedControlPlane to False with the given reason and message.
+func (s *ManagedControlPlaneScope) MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.GCPManagedControlPlane, t, reason, severity, "%s", message)
+}
+
+// ANCHOR_END: ManagedControlPlaneSetter
+
+// ANCHOR: ManagedControlPlaneClusterSpec
+
+// ClusterSpec returns the GKE cluster spec.
+func (s *ManagedControlPlaneScope) ClusterSpec() *container.Cluster {
+	network := s.GCPManagedCluster.Spec.Network
+	networkProject := pointer.StringDeref(network.HostProject, s.Project())
+	cluster := &container.Cluster{
+		Name:    s.ClusterName(),
+		Network: fmt.Sprintf("projects/%s/global/networks/%s", networkProject, pointer.StringDeref(network.Name, "default")),
+		ResourceLabels: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Additional:  s.GCPManagedCluster.Spec.AdditionalLabels,
+		}),
+	}
+
+	// Custom mode networks require the subnetwork of the cluster, GKE uses the first subnetwork of the region.
+	for _, subnet := range network.Subnets {
+		if subnet.Region == "" || subnet.Region == s.Region() {
+			cluster.Subnetwork = fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", networkProject, s.Region(), subnet.Name)
+			break
+		}
+	}
+
+	if version := s.DesiredVersion(); version != nil {
+		cluster.InitialClusterVersion = *version
+	}
+
+	if channel := s.DesiredReleaseChannel(); channel != "" {
+		cluster.ReleaseChannel = &container.ReleaseChannel{Channel: channel}
+	}
+
+	if s.GCPManagedControlPlane.Spec.EnableAutopilot {
+		cluster.Autopilot = &container.Autopilot{Enabled: true}
+	}
+
+	return cluster
+}
+
+// NodePoolSpecs returns the GKE node pools of the GCPManagedMachinePools of the cluster. Standard clusters
+// require at least one node pool to be created, the node pools are created along with the cluster.
+func (s *ManagedControlPlaneScope) NodePoolSpecs(ctx context.Context) ([]*container.NodePool, error) {
+	if s.GCPManagedControlPlane.Spec.EnableAutopilot {
+		return nil, nil
+	}
+
+	gcpManagedMachinePools := &infrav1.GCPManagedMachinePoolList{}
+	if err := s.client.List(ctx, gcpManagedMachinePools,
+		client.InNamespace(s.Namespace()),
+		client.MatchingLabels{clusterv1.ClusterLabelName: s.Name()},
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to list GCPManagedMachinePools")
+	}
+
+	nodePools := make([]*container.NodePool, 0, len(gcpManagedMachinePools.Items))
+	for i := range gcpManagedMachinePools.Items {
+		gcpManagedMachinePool := &gcpManagedMachinePools.Items[i]
+		if !gcpManagedMachinePool.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		machinePool, err := exputil.GetOwnerMachinePool(ctx, s.client, gcpManagedMachinePool.ObjectMeta)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get MachinePool of GCPManagedMachinePool %s", gcpManagedMachinePool.Name)
+		}
+		if machinePool == nil {
+			continue
+		}
+
+		nodePools = append(nodePools, nodePoolSpec(gcpManagedMachinePool, machinePool, 0))
+	}
+
+	return nodePools, nil
+}
+
+// ANCHOR_END: ManagedControlPlaneClusterSpec
+
+// gkeClusterName returns the name of the GKE cluster of a GCPManagedControlPlane.
+func gkeClusterName(cluster *clusterv1.Cluster, gcpManagedControlPlane *infrav1.GCPManagedControlPlane) string {
+	if gcpManagedControlPlane.Spec.ClusterName != "" {
+		return gcpManagedControlPlane.Spec.ClusterName
+	}
+
+	return cluster.Name
+}
+
+// PatchObject persists the managed control plane configuration and status.
+func (s *ManagedControlPlaneScope) PatchObject() error {
+	conditions.SetSummary(s.GCPManagedControlPlane,
+		conditions.WithConditions(
+			infrav1.GKEControlPlaneReadyCondition,
+			infrav1.KubeconfigReadyCondition,
+		),
+	)
+
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.GCPManagedControlPlane,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.GKEControlPlaneReadyCondition,
+			infrav1.KubeconfigReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the managed control plane configuration and status.
+func (s *ManagedControlPlaneScope) Close() error {
+	return s.PatchObject()
+}
@@ -0,0 +1,368 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultNodePoolZones is the number of zones GKE spreads the nodes of a regional cluster over by default.
+const defaultNodePoolZones = 3
+
+// taintEffects maps the Kubernetes taint effects to the GKE taint effects.
+var taintEffects = map[infrav1.TaintEffect]string{
+	infrav1.TaintEffectNoSchedule:       "NO_SCHEDULE",
+	infrav1.TaintEffectPreferNoSchedule: "PREFER_NO_SCHEDULE",
+	infrav1.TaintEffectNoExecute:        "NO_EXECUTE",
+}
+
+// ManagedMachinePoolScopeParams defines the input parameters used to create a new ManagedMachinePoolScope.
+type ManagedMachinePoolScopeParams struct {
+	GCPServices
+	Client                 client.Client
+	Cluster                *clusterv1.Cluster
+	MachinePool            *expclusterv1.MachinePool
+	GCPManagedCluster      *infrav1.GCPManagedCluster
+	GCPManagedControlPlane *infrav1.GCPManagedControlPlane
+	GCPManagedMachinePool  *infrav1.GCPManagedMachinePool
+}
+
+// NewManagedMachinePoolScope creates a new ManagedMachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewManagedMachinePoolScope(params ManagedMachinePoolScopeParams) (*ManagedMachinePoolScope, error) {
+	if params.Cluster == nil {
+		return nil, errors.New("failed to generate new scope from nil Cluster")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("failed to generate new scope from nil MachinePool")
+	}
+	if params.GCPManagedCluster == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedCluster")
+	}
+	if params.GCPManagedControlPlane == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedControlPlane")
+	}
+	if params.GCPManagedMachinePool == nil {
+		return nil, errors.New("failed to generate new scope from nil GCPManagedMachinePool")
+	}
+
+	if params.GCPServices.Compute == nil || params.GCPServices.Container == nil {
+		opts, err := identityClientOptions(context.TODO(), params.Client, params.GCPManagedCluster.Spec.IdentityRef, params.GCPManagedCluster.Namespace)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp clients: %v", err)
+		}
+
+		if params.GCPServices.Compute == nil {
+			computeSvc, err := compute.NewService(context.TODO(), opts...)
+			if err != nil {
+				return nil, errors.Errorf("failed to create gcp compute client: %v", err)
+			}
+
+			params.GCPServices.Compute = computeSvc
+		}
+
+		if params.GCPServices.Container == nil {
+			containerSvc, err := container.NewService(context.TODO(), opts...)
+			if err != nil {
+				return nil, errors.Errorf("failed to create gcp container client: %v", err)
+			}
+
+			params.GCPServices.Container = containerSvc
+		}
+	}
+
+	helper, err := patch.NewHelper(params.GCPManagedMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &ManagedMachinePoolScope{
+		client:                 params.Client,
+		Cluster:                params.Cluster,
+		MachinePool:            params.MachinePool,
+		GCPManagedCluster:      params.GCPManagedCluster,
+		GCPManagedControlPlane: params.GCPManagedControlPlane,
+		GCPManagedMachinePool:  params.GCPManagedMachinePool,
+		GCPServices:            params.GCPServices,
+		patchHelper:            helper,
+	}, nil
+}
+
+// ManagedMachinePoolScope defines the basic context for an actuator to operate upon a GKE node pool.
+type ManagedMachinePoolScope struct {
+	client      client.Client
+	patchHelper *patch.Helper
+
+	Cluster                *clusterv1.Cluster
+	MachinePool            *expclusterv1.MachinePool
+	GCPManagedCluster      *infrav1.GCPManagedCluster
+	GCPManagedControlPlane *infrav1.GCPManagedControlPlane
+	GCPManagedMachinePool  *infrav1.GCPManagedMachinePool
+	GCPServices
+}
+
+// ANCHOR: ManagedMachinePoolGetter
+
+// ComputeService returns the compute service used to list the instances of the node pool.
+func (s *ManagedMachinePoolScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
+// ContainerService returns the container service used to manage the node pool.
+func (s *ManagedMachinePoolScope) ContainerService() *container.Service {
+	return s.GCPServices.Container
+}
+
+// Name returns the GCPManagedMachinePool name.
+func (s *ManagedMachinePoolScope) Name() string {
+	return s.GCPManagedMachinePool.Name
+}
+
+// Namespace returns the namespace name.
+func (s *ManagedMachinePoolScope) Namespace() string {
+	return s.GCPManagedMachinePool.Namespace
+}
+
+// NodePoolName returns the name of the GKE node pool.
+func (s *ManagedMachinePoolScope) NodePoolName() string {
+	return nodePoolName(s.GCPManagedMachinePool)
+}
+
+// ClusterFullName returns the name of the GKE cluster, in the format `projects/*/locations/*/clusters/*`.
+func (s *ManagedMachinePoolScope) ClusterFullName() string {
+	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s",
+		s.GCPManagedCluster.Spec.Project, s.GCPManagedCluster.Spec.Region, gkeClusterName(s.Cluster, s.GCPManagedControlPlane))
+}
+
+// NodePoolFullName returns the name of the GKE node pool, in the format `projects/*/locations/*/clusters/*/nodePools/*`.
+func (s *ManagedMachinePoolScope) NodePoolFullName() string {
+	return fmt.Sprintf("%s/nodePools/%s", s.ClusterFullName(), s.NodePoolName())
+}
+
+// DesiredVersion returns the Kubernetes version requested for the nodes, without the leading v.
+func (s *ManagedMachinePoolScope) DesiredVersion() *string {
+	return nodePoolVersion(s.MachinePool)
+}
+
+// NodeCountPerZone returns the number of nodes per zone matching the replicas of the MachinePool,
+// for a node pool spread over the given number of zones.
+func (s *ManagedMachinePoolScope) NodeCountPerZone(zones int) int64 {
+	return nodeCountPerZone(s.MachinePool, zones)
+}
+
+// NodePoolAutoscaling returns the autoscaling settings of the node pool.
+func (s *ManagedMachinePoolScope) NodePoolAutoscaling() *container.NodePoolAutoscaling {
+	return nodePoolAutoscaling(s.GCPManagedMachinePool)
+}
+
+// NodePoolLabels returns the Kubernetes labels of the nodes.
+func (s *ManagedMachinePoolScope) NodePoolLabels() map[string]string {
+	return s.GCPManagedMachinePool.Spec.KubernetesLabels
+}
+
+// NodePoolTaints returns the Kubernetes taints of the nodes.
+func (s *ManagedMachinePoolScope) NodePoolTaints() []*container.NodeTaint {
+	return nodePoolTaints(s.GCPManagedMachinePool)
+}
+
+// NodePoolUpgradeSettings returns the surge upgrade settings of the node pool, or nil when GKE defaults are used.
+func (s *ManagedMachinePoolScope) NodePoolUpgradeSettings() *container.UpgradeSettings {
+	return nodePoolUpgradeSettings(s.GCPManagedMachinePool)
+}
+
+// ANCHOR_END: ManagedMachinePoolGetter
+
+// ANCHOR: ManagedMachinePoolSetter
+
+// SetReady sets the GCPManagedMachinePool Ready Status.
+func (s *ManagedMachinePoolScope) SetReady() {
+	s.GCPManagedMachinePool.Status.Ready = true
+}
+
+// SetNotReady sets the GCPManagedMachinePool Ready Status to false.
+func (s *ManagedMachinePoolScope) SetNotReady() {
+	s.GCPManagedMachinePool.Status.Ready = false
+}
+
+// SetReplicas sets the number of running nodes of the GCPManagedMachinePool.
+func (s *ManagedMachinePoolScope) SetReplicas(replicas int32) {
+	s.GCPManagedMachinePool.Status.Replicas = replicas
+}
+
+// SetProviderIDList sets the provider IDs of the nodes of the GCPManagedMachinePool.
+func (s *ManagedMachinePoolScope) SetProviderIDList(providerIDs []string) {
+	s.GCPManagedMachinePool.Spec.ProviderIDList = providerIDs
+}
+
+// MarkConditionTrue sets the condition of the GCPManagedMachinePool to True.
+func (s *ManagedMachinePoolScope) MarkConditionTrue(t clusterv1.ConditionType) {
+	conditions.MarkTrue(s.GCPManagedMachinePool, t)
+}
+
+// MarkConditionFalse sets the condition of the GCPManagedMachinePool to False with the given reason and message.
+func (s *ManagedMachinePoolScope) MarkConditionFalse(t clusterv1.ConditionType, reason string, severity clusterv1.ConditionSeverity, message string) {
+	conditions.MarkFalse(s.GCPManagedMachinePool, t, reason, severity, "%s", message)
+}
+
+// ANCHOR_END: ManagedMachinePoolSetter
+
+// ANCHOR: ManagedMachinePoolNodePoolSpec
+
+// NodePoolSpec returns the GKE node pool spec, for a node pool spread over the given number of zones.
+func (s *ManagedMachinePoolScope) NodePoolSpec(zones int) *container.NodePool {
+	return nodePoolSpec(s.GCPManagedMachinePool, s.MachinePool, zones)
+}
+
+// nodePoolSpec returns the GKE node pool spec of a GCPManagedMachinePool and its MachinePool, for a node
+// pool spread over the given number of zones. It is shared with the node pools of the cluster creation.
+func nodePoolSpec(gcpManagedMachinePool *infrav1.GCPManagedMachinePool, machinePool *expclusterv1.MachinePool, zones int) *container.NodePool {
+	spec := gcpManagedMachinePool.Spec
+	nodePool := &container.NodePool{
+		Name:             nodePoolName(gcpManagedMachinePool),
+		InitialNodeCount: nodeCountPerZone(machinePool, zones),
+		Autoscaling:      nodePoolAutoscaling(gcpManagedMachinePool),
+		UpgradeSettings:  nodePoolUpgradeSettings(gcpManagedMachinePool),
+		Config: &container.NodeConfig{
+			MachineType: pointer.StringDeref(spec.MachineType, ""),
+			DiskSizeGb:  pointer.Int64Deref(spec.DiskSizeGB, 0),
+			Labels:      spec.KubernetesLabels,
+			Taints:      nodePoolTaints(gcpManagedMachinePool),
+			Spot:        spec.Spot,
+		},
+	}
+
+	if spec.DiskType != nil {
+		nodePool.Config.DiskType = string(*spec.DiskType)
+	}
+
+	if version := nodePoolVersion(machinePool); version != nil {
+		nodePool.Version = *version
+	}
+
+	return nodePool
+}
+
+// ANCHOR_END: ManagedMachinePoolNodePoolSpec
+
+// nodePoolName returns the name of the GKE node pool of a GCPManagedMachinePool.
+func nodePoolName(gcpManagedMachinePool *infrav1.GCPManagedMachinePool) string {
+	if gcpManagedMachinePool.Spec.NodePoolName != "" {
+		return gcpManagedMachinePool.Spec.NodePoolName
+	}
+
+	return gcpManagedMachinePool.Name
+}
+
+// nodePoolVersion returns the Kubernetes version of the MachinePool without the leading v.
+func nodePoolVersion(machinePool *expclusterv1.MachinePool) *string {
+	if machinePool.Spec.Template.Spec.Version == nil {
+		return nil
+	}
+
+	return pointer.String(strings.TrimPrefix(*machinePool.Spec.Template.Spec.Version, "v"))
+}
+
+// nodeCountPerZone returns the number of nodes per zone of the node pool: GKE sizes node pools per zone
+// while the replicas of the MachinePool are the total number of nodes. The number of zones defaults to
+// the GKE default when it is not known yet.
+func nodeCountPerZone(machinePool *expclusterv1.MachinePool, zones int) int64 {
+	if zones <= 0 {
+		zones = defaultNodePoolZones
+	}
+
+	replicas := int64(pointer.Int32Deref(machinePool.Spec.Replicas, 1))
+	return (replicas + int64(zones) - 1) / int64(zones)
+}
+
+// nodePoolAutoscaling returns the autoscaling settings of the node pool of a GCPManagedMachinePool.
+func nodePoolAutoscaling(gcpManagedMachinePool *infrav1.GCPManagedMachinePool) *container.NodePoolAutoscaling {
+	scaling := gcpManagedMachinePool.Spec.Scaling
+	if scaling == nil {
+		return &container.NodePoolAutoscaling{Enabled: false, ForceSendFields: []string{"Enabled"}}
+	}
+
+	return &container.NodePoolAutoscaling{
+		Enabled:         true,
+		MinNodeCount:    int64(scaling.MinCount),
+		MaxNodeCount:    int64(scaling.MaxCount),
+		ForceSendFields: []string{"MinNodeCount"},
+	}
+}
+
+// nodePoolTaints returns the GKE taints of the nodes of a GCPManagedMachinePool.
+func nodePoolTaints(gcpManagedMachinePool *infrav1.GCPManagedMachinePool) []*container.NodeTaint {
+	taints := make([]*container.NodeTaint, 0, len(gcpManagedMachinePool.Spec.KubernetesTaints))
+	for _, taint := range gcpManagedMachinePool.Spec.KubernetesTaints {
+		taints = append(taints, &container.NodeTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: taintEffects[taint.Effect],
+		})
+	}
+
+	return taints
+}
+
+// nodePoolUpgradeSettings returns the surge upgrade settings of the node pool of a GCPManagedMachinePool.
+func nodePoolUpgradeSettings(gcpManagedMachinePool *infrav1.GCPManagedMachinePool) *container.UpgradeSettings {
+	settings := gcpManagedMachinePool.Spec.UpgradeSettings
+	if settings == nil {
+		return nil
+	}
+
+	// GKE upgrades a single node at a time by default.
+	return &container.UpgradeSettings{
+		MaxSurge:        int64(pointer.Int32Deref(settings.MaxSurge, 1)),
+		MaxUnavailable:  int64(pointer.Int32Deref(settings.MaxUnavailable, 0)),
+		ForceSendFields: []string{"MaxSurge", "MaxUnavailable"},
+	}
+}
+
+// PatchObject persists the managed machine pool configuration and status.
+func (s *ManagedMachinePoolScope) PatchObject() error {
+	conditions.SetSummary(s.GCPManagedMachinePool,
+		conditions.WithConditions(
+			infrav1.GKENodePoolReadyCondition,
+		),
+	)
+
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.GCPManagedMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			infrav1.GKENodePoolReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the managed machine pool configuration and status.
+func (s *ManagedMachinePoolScope) Close() error {
+	return s.PatchObject()
+}
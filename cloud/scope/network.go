@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+// The network specs are shared by the scopes of the self-managed and GKE clusters.
+
+// networkSpec returns the google compute network spec of the cluster network.
+func networkSpec(clusterName, networkName string, spec infrav1.NetworkSpec) *compute.Network {
+	createSubnet := pointer.BoolDeref(spec.AutoCreateSubnetworks, true)
+	network := &compute.Network{
+		Name:                  networkName,
+		Description:           infrav1.ClusterTagKey(clusterName),
+		AutoCreateSubnetworks: createSubnet,
+		ForceSendFields:       []string{"AutoCreateSubnetworks"},
+	}
+
+	return network
+}
+
+// subnetSpecs returns the google compute subnets spec of the cluster network.
+func subnetSpecs(clusterName, clusterRegion string, spec infrav1.NetworkSpec) []*compute.Subnetwork {
+	subnets := make([]*compute.Subnetwork, 0, len(spec.Subnets))
+	for _, subnet := range spec.Subnets {
+		rangeNames := make([]string, 0, len(subnet.SecondaryCidrBlocks))
+		for rangeName := range subnet.SecondaryCidrBlocks {
+			rangeNames = append(rangeNames, rangeName)
+		}
+		sort.Strings(rangeNames)
+
+		secondaryRanges := make([]*compute.SubnetworkSecondaryRange, 0, len(rangeNames))
+		for _, rangeName := range rangeNames {
+			secondaryRanges = append(secondaryRanges, &compute.SubnetworkSecondaryRange{
+				RangeName:   rangeName,
+				IpCidrRange: subnet.SecondaryCidrBlocks[rangeName],
+			})
+		}
+
+		region := subnet.Region
+		if region == "" {
+			region = clusterRegion
+		}
+
+		subnets = append(subnets, &compute.Subnetwork{
+			Name:                  subnet.Name,
+			Description:           subnetDescription(clusterName, subnet.Description),
+			Region:                region,
+			IpCidrRange:           subnet.CidrBlock,
+			SecondaryIpRanges:     secondaryRanges,
+			PrivateIpGoogleAccess: pointer.BoolDeref(subnet.PrivateGoogleAccess, false),
+			EnableFlowLogs:        pointer.BoolDeref(subnet.EnableFlowLogs, false),
+		})
+	}
+
+	return subnets
+}
+
+// subnetDescription returns the description of a subnetwork of the cluster network. Subnetworks have no labels,
+// so the description always starts with the cluster tag marking the subnetwork as created by capg, followed by
+// the description of the spec if any.
+func subnetDescription(clusterName string, description *string) string {
+	if description == nil || *description == "" {
+		return infrav1.ClusterTagKey(clusterName)
+	}
+
+	return fmt.Sprintf("%s: %s", infrav1.ClusterTagKey(clusterName), *description)
+}
+
+// natRouterSpec returns the google compute nat router spec of the cluster network.
+func natRouterSpec(networkName string) *compute.Router {
+	return &compute.Router{
+		Name: fmt.Sprintf("%s-%s", networkName, "router"),
+		Nats: []*compute.RouterNat{
+			{
+				Name:                          fmt.Sprintf("%s-%s", networkName, "nat"),
+				NatIpAllocateOption:           "AUTO_ONLY",
+				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
+			},
+		},
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastions
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconcile cluster bastion host components.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling bastion host resources")
+	if s.scope.BastionSpec() == nil {
+		if s.scope.BastionStatus() == nil {
+			return nil
+		}
+
+		// The bastion host has been removed from the spec.
+		return s.Delete(ctx)
+	}
+
+	instance, err := s.createOrGetInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	status := instance.Status
+	s.scope.SetBastionStatus(&infrav1.BastionStatus{
+		SelfLink:       pointer.String(instance.SelfLink),
+		InstanceStatus: (*infrav1.InstanceStatus)(&status),
+		Addresses:      addressesFromInstance(instance),
+	})
+
+	return nil
+}
+
+// Delete delete cluster bastion host components.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting bastion host resources")
+	instanceKey := s.instanceKey()
+	if instanceKey == nil {
+		s.scope.SetBastionStatus(nil)
+		return nil
+	}
+
+	log.V(2).Info("Looking for bastion instance before deleting", "name", instanceKey.Name, "zone", instanceKey.Zone)
+	instance, err := s.instances.Get(ctx, instanceKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for bastion instance before deleting", "name", instanceKey.Name)
+			return err
+		}
+
+		s.scope.SetBastionStatus(nil)
+		return nil
+	}
+
+	if !infrav1.Labels(instance.Labels).HasOwned(s.scope.Name()) {
+		log.V(2).Info("Bastion instance is not owned by the cluster, skipping deletion", "name", instanceKey.Name)
+		s.scope.SetBastionStatus(nil)
+		return nil
+	}
+
+	log.V(2).Info("Deleting bastion instance", "name", instanceKey.Name, "zone", instanceKey.Zone)
+	if err := gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey)); err != nil {
+		log.Error(err, "Error deleting bastion instance", "name", instanceKey.Name)
+		return err
+	}
+
+	s.scope.SetBastionStatus(nil)
+	return nil
+}
+
+func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
+	log := log.FromContext(ctx)
+	instanceSpec := s.scope.BastionSpec()
+	if instanceSpec.Zone == "" {
+		return nil, errors.New("failed to determine the zone of the bastion host, no failure domain is available")
+	}
+
+	instanceKey := meta.ZonalKey(instanceSpec.Name, instanceSpec.Zone)
+	log.V(2).Info("Looking for bastion instance", "name", instanceSpec.Name, "zone", instanceSpec.Zone)
+	instance, err := s.instances.Get(ctx, instanceKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for bastion instance", "name", instanceSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating bastion instance", "name", instanceSpec.Name, "zone", instanceSpec.Zone)
+		if err := s.instances.Insert(ctx, instanceKey, instanceSpec); err != nil {
+			log.Error(err, "Error creating bastion instance", "name", instanceSpec.Name)
+			return nil, err
+		}
+
+		instance, err = s.instances.Get(ctx, instanceKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+// instanceKey returns the key of the bastion instance, from its self link when it was created,
+// or nil when its zone is unknown.
+func (s *Service) instanceKey() *meta.Key {
+	if status := s.scope.BastionStatus(); status != nil && status.SelfLink != nil {
+		if id, err := cloud.ParseResourceURL(*status.SelfLink); err == nil && id.Key.Type() == meta.Zonal {
+			return id.Key
+		}
+	}
+
+	zone := s.scope.BastionZone()
+	if zone == "" {
+		return nil
+	}
+
+	return meta.ZonalKey(s.scope.BastionInstanceName(), zone)
+}
+
+// addressesFromInstance returns the internal and external IP addresses of the instance.
+func addressesFromInstance(instance *compute.Instance) []corev1.NodeAddress {
+	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
+	for _, iface := range instance.NetworkInterfaces {
+		addresses = append(addresses, corev1.NodeAddress{
+			Type:    corev1.NodeInternalIP,
+			Address: iface.NetworkIP,
+		})
+
+		for _, ac := range iface.AccessConfigs {
+			addresses = append(addresses, corev1.NodeAddress{
+				Type:    corev1.NodeExternalIP,
+				Address: ac.NatIP,
+			})
+		}
+	}
+
+	return addresses
+}
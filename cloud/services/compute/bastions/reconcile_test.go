@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+		},
+		Bastion: &infrav1.BastionSpec{
+			AllowedSourceRanges: []string{"203.0.113.0/24"},
+		},
+	},
+	Status: infrav1.GCPClusterStatus{
+		FailureDomains: clusterv1.FailureDomains{
+			"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+		},
+	},
+}
+
+func newClusterScope(t *testing.T, gcpCluster *infrav1.GCPCluster) *scope.ClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  gcpCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+// newMockInstances returns the mock instances setting the status, self link and addresses of the created instances.
+func newMockInstances() *cloud.MockInstances {
+	return &cloud.MockInstances{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+		InsertHook: func(_ context.Context, key *meta.Key, obj *compute.Instance, m *cloud.MockInstances) (bool, error) {
+			obj.Status = string(infrav1.InstanceStatusRunning)
+			obj.SelfLink = cloud.SelfLink(meta.VersionGA, "my-proj", "instances", key)
+			obj.NetworkInterfaces[0].NetworkIP = "10.0.0.2"
+			for _, ac := range obj.NetworkInterfaces[0].AccessConfigs {
+				ac.NatIP = "198.51.100.2"
+			}
+			m.Objects[*key] = &cloud.MockInstancesObj{Obj: obj}
+			return true, nil
+		},
+	}
+}
+
+func TestService_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+	clusterScope := newClusterScope(t, fakeGCPCluster.DeepCopy())
+	instances := newMockInstances()
+	s := &Service{
+		scope:     clusterScope,
+		instances: instances,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	instance, err := instances.Get(ctx, meta.ZonalKey("my-cluster-bastion", "us-central1-a"))
+	if err != nil {
+		t.Fatalf("Service.Reconcile() bastion instance not created in the first failure domain: %v", err)
+	}
+	if instance.MachineType != "zones/us-central1-a/machineTypes/e2-micro" {
+		t.Errorf("Service.Reconcile() machine type = %s, want e2-micro", instance.MachineType)
+	}
+	if d := cmp.Diff([]string{"my-cluster-bastion"}, instance.Tags.Items); d != "" {
+		t.Errorf("Service.Reconcile() tags mismatch (-want +got):\n%s", d)
+	}
+
+	running := infrav1.InstanceStatusRunning
+	wantStatus := &infrav1.BastionStatus{
+		SelfLink:       pointer.String("https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-cluster-bastion"),
+		InstanceStatus: &running,
+		Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+			{Type: corev1.NodeExternalIP, Address: "198.51.100.2"},
+		},
+	}
+	if d := cmp.Diff(wantStatus, clusterScope.BastionStatus()); d != "" {
+		t.Errorf("Service.Reconcile() status mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestService_Reconcile_removedBastion(t *testing.T) {
+	ctx := context.TODO()
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	clusterScope := newClusterScope(t, gcpCluster)
+	instances := newMockInstances()
+	s := &Service{
+		scope:     clusterScope,
+		instances: instances,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	// Removing the bastion host from the spec deletes the instance, even if the failure domains changed.
+	gcpCluster.Spec.Bastion = nil
+	gcpCluster.Status.FailureDomains = clusterv1.FailureDomains{
+		"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true},
+	}
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	if len(instances.Objects) != 0 {
+		t.Errorf("Service.Reconcile() instances = %v, want none", instances.Objects)
+	}
+	if clusterScope.BastionStatus() != nil {
+		t.Errorf("Service.Reconcile() status = %v, want nil", clusterScope.BastionStatus())
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.TODO()
+	instances := newMockInstances()
+	instances.Objects[*meta.ZonalKey("my-cluster-bastion", "us-central1-a")] = &cloud.MockInstancesObj{Obj: &compute.Instance{
+		Name:   "my-cluster-bastion",
+		Labels: map[string]string{"capg-cluster-my-cluster-other": "owned"},
+	}}
+	s := &Service{
+		scope:     newClusterScope(t, fakeGCPCluster.DeepCopy()),
+		instances: instances,
+	}
+
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Service.Delete() error = %v", err)
+	}
+
+	if len(instances.Objects) != 1 {
+		t.Errorf("Service.Delete() deleted an instance not owned by the cluster")
+	}
+}
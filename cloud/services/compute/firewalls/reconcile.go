@@ -18,8 +18,15 @@ package firewalls
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -28,29 +35,62 @@ import (
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling firewall resources")
-	for _, spec := range s.scope.FirewallRulesSpec() {
-		log.V(2).Info("Looking firewall", "name", spec.Name)
-		firewallKey := meta.GlobalKey(spec.Name)
-		if _, err := s.firewalls.Get(ctx, firewallKey); err != nil {
-			if !gcperrors.IsNotFound(err) {
-				return err
-			}
+	specs := s.scope.FirewallRulesSpec()
+	firewallRules := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		firewall, err := s.createOrUpdateFirewall(ctx, spec)
+		if err != nil {
+			return err
+		}
 
-			log.V(2).Info("Creating firewall", "name", spec.Name)
-			if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
-				return err
-			}
+		firewallRules[spec.Name] = firewall.SelfLink
+	}
+
+	s.scope.Network().FirewallRules = firewallRules
+	return s.deleteStaleFirewallRules(ctx, specs)
+}
+
+// createOrUpdateFirewall creates the firewall if not exist, or updates it when it drifted from the spec.
+func (s *Service) createOrUpdateFirewall(ctx context.Context, spec *compute.Firewall) (*compute.Firewall, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking firewall", "name", spec.Name)
+	firewallKey := meta.GlobalKey(spec.Name)
+	firewall, err := s.firewalls.Get(ctx, firewallKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for firewall", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating firewall", "name", spec.Name)
+		if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
+			log.Error(err, "Error creating firewall", "name", spec.Name)
+			return nil, err
 		}
+
+		return s.firewalls.Get(ctx, firewallKey)
 	}
 
-	return nil
+	if firewallMatches(firewall, spec) {
+		return firewall, nil
+	}
+
+	// The update replaces the whole rule, so the changes made outside of the spec are reverted too.
+	log.V(2).Info("Updating firewall", "name", spec.Name)
+	if err := s.firewalls.Update(ctx, firewallKey, spec); err != nil {
+		log.Error(err, "Error updating firewall", "name", spec.Name)
+		return nil, err
+	}
+
+	return s.firewalls.Get(ctx, firewallKey)
 }
 
 // Delete delete cluster firewall compoenents.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Deleting firewall resources")
-	for _, spec := range s.scope.FirewallRulesSpec() {
+	specs := s.scope.FirewallRulesSpec()
+	for _, spec := range specs {
 		log.V(2).Info("Deleting firewall", "name", spec.Name)
 		firewallKey := meta.GlobalKey(spec.Name)
 		if err := s.firewalls.Delete(ctx, firewallKey); err != nil {
@@ -59,7 +99,99 @@ func (s *Service) Delete(ctx context.Context) error {
 				return err
 			}
 		}
+
+		delete(s.scope.Network().FirewallRules, spec.Name)
+	}
+
+	return s.deleteStaleFirewallRules(ctx, nil)
+}
+
+// deleteStaleFirewallRules deletes the firewall rules owned by the cluster which are not part of the given specs,
+// e.g. the user-defined rules removed from the cluster spec.
+func (s *Service) deleteStaleFirewallRules(ctx context.Context, specs []*compute.Firewall) error {
+	log := log.FromContext(ctx)
+	desired := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		desired[spec.Name] = true
+	}
+
+	firewalls, err := s.firewalls.List(ctx, filter.Regexp("name", s.scope.FirewallRulePrefix()+".*"))
+	if err != nil {
+		log.Error(err, "Error listing firewalls", "prefix", s.scope.FirewallRulePrefix())
+		return err
+	}
+
+	for _, firewall := range firewalls {
+		// Rules of other clusters may share the name prefix, only the rules owned by this cluster are deleted.
+		if desired[firewall.Name] || firewall.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+			continue
+		}
+
+		log.V(2).Info("Deleting stale firewall", "name", firewall.Name)
+		if err := s.firewalls.Delete(ctx, meta.GlobalKey(firewall.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting stale firewall", "name", firewall.Name)
+			return err
+		}
 	}
 
 	return nil
 }
+
+// firewallMatches returns true if the observed firewall matches the spec. The protocols, ranges, tags and service
+// accounts are compared in any order.
+func firewallMatches(current, desired *compute.Firewall) bool {
+	return current.Description == desired.Description &&
+		strings.EqualFold(current.Direction, desired.Direction) &&
+		current.Priority == desired.Priority &&
+		current.Disabled == desired.Disabled &&
+		stringSetsMatch(allowedProtocols(current.Allowed), allowedProtocols(desired.Allowed)) &&
+		stringSetsMatch(deniedProtocols(current.Denied), deniedProtocols(desired.Denied)) &&
+		stringSetsMatch(current.SourceRanges, desired.SourceRanges) &&
+		stringSetsMatch(current.DestinationRanges, desired.DestinationRanges) &&
+		stringSetsMatch(current.SourceTags, desired.SourceTags) &&
+		stringSetsMatch(current.TargetTags, desired.TargetTags) &&
+		stringSetsMatch(current.SourceServiceAccounts, desired.SourceServiceAccounts) &&
+		stringSetsMatch(current.TargetServiceAccounts, desired.TargetServiceAccounts)
+}
+
+// allowedProtocols returns the allowed protocols and ports of a firewall, e.g. tcp:22,80.
+func allowedProtocols(allowed []*compute.FirewallAllowed) []string {
+	protocols := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		protocols = append(protocols, protocolKey(a.IPProtocol, a.Ports))
+	}
+
+	return protocols
+}
+
+// deniedProtocols returns the denied protocols and ports of a firewall, e.g. tcp:22,80.
+func deniedProtocols(denied []*compute.FirewallDenied) []string {
+	protocols := make([]string, 0, len(denied))
+	for _, d := range denied {
+		protocols = append(protocols, protocolKey(d.IPProtocol, d.Ports))
+	}
+
+	return protocols
+}
+
+// protocolKey returns the representation of a protocol and its ports. GCP reports protocols in lower case.
+func protocolKey(protocol string, ports []string) string {
+	sorted := append([]string{}, ports...)
+	sort.Strings(sorted)
+
+	return fmt.Sprintf("%s:%s", strings.ToLower(protocol), strings.Join(sorted, ","))
+}
+
+// stringSetsMatch returns true if both lists hold the same strings, in any order.
+func stringSetsMatch(current, desired []string) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	a := append([]string{}, current...)
+	b := append([]string{}, desired...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	return reflect.DeepEqual(a, b)
+}
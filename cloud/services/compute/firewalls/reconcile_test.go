@@ -0,0 +1,504 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewalls
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+			FirewallRules: infrav1.FirewallRules{
+				{
+					Name:         "ssh",
+					Protocols:    []infrav1.FirewallRuleProtocol{{Protocol: "tcp", Ports: []string{"22"}}},
+					SourceRanges: []string{"10.0.0.0/8"},
+					TargetTags:   []string{"my-cluster-node"},
+				},
+			},
+		},
+	},
+}
+
+func newClusterScope(t *testing.T) *scope.ClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+// newMockFirewalls returns the mock firewalls holding a drifted rule and a stale rule of the cluster, and a rule of
+// another cluster sharing the name prefix. The updated rules are recorded in updates.
+func newMockFirewalls(updates *[]string) *cloud.MockFirewalls {
+	return &cloud.MockFirewalls{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls) error {
+			*updates = append(*updates, key.Name)
+			obj.SelfLink = cloud.SelfLink(meta.VersionGA, "my-proj", "firewalls", key)
+			m.Objects[*key] = &cloud.MockFirewallsObj{Obj: obj}
+			return nil
+		},
+		Objects: map[meta.Key]*cloud.MockFirewallsObj{
+			*meta.GlobalKey("allow-my-cluster-cluster"): {Obj: &compute.Firewall{
+				Name:       "allow-my-cluster-cluster",
+				Direction:  "INGRESS",
+				Priority:   1000,
+				Allowed:    []*compute.FirewallAllowed{{IPProtocol: "all"}},
+				SourceTags: []string{"my-cluster-node", "my-cluster-control-plane"},
+				TargetTags: []string{"my-cluster-node", "my-cluster-control-plane"},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/my-proj/global/firewalls/allow-my-cluster-cluster",
+			}},
+			*meta.GlobalKey("allow-my-cluster-healthchecks"): {Obj: &compute.Firewall{
+				Name:         "allow-my-cluster-healthchecks",
+				Direction:    "INGRESS",
+				Priority:     1000,
+				Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"443"}}},
+				SourceRanges: []string{"35.191.0.0/16", "130.211.0.0/22"},
+				TargetTags:   []string{"my-cluster-control-plane"},
+			}},
+			*meta.GlobalKey("my-cluster-removed"): {Obj: &compute.Firewall{
+				Name:        "my-cluster-removed",
+				Description: infrav1.ClusterTagKey("my-cluster"),
+			}},
+			*meta.GlobalKey("my-cluster-other-ssh"): {Obj: &compute.Firewall{
+				Name:        "my-cluster-other-ssh",
+				Description: infrav1.ClusterTagKey("my-cluster-other"),
+			}},
+		},
+	}
+}
+
+func firewallNames(t *testing.T, firewalls *cloud.MockFirewalls) []string {
+	t.Helper()
+
+	names := []string{}
+	for key := range firewalls.Objects {
+		names = append(names, key.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func TestService_Reconcile(t *testing.T) {
+	ctx := context.TODO()
+	updates := []string{}
+	firewalls := newMockFirewalls(&updates)
+	clusterScope := newClusterScope(t)
+	s := &Service{
+		scope:     clusterScope,
+		firewalls: firewalls,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	if d := cmp.Diff([]string{"allow-my-cluster-healthchecks"}, updates); d != "" {
+		t.Errorf("Service.Reconcile() updates mismatch (-want +got):\n%s", d)
+	}
+
+	healthChecks, err := firewalls.Get(ctx, meta.GlobalKey("allow-my-cluster-healthchecks"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := allowedProtocols(healthChecks.Allowed); len(got) != 1 || got[0] != "tcp:6443" {
+		t.Errorf("Service.Reconcile() health checks firewall allows %v, want tcp:6443", got)
+	}
+
+	wantStatus := map[string]string{
+		"allow-my-cluster-cluster":      "https://www.googleapis.com/compute/v1/projects/my-proj/global/firewalls/allow-my-cluster-cluster",
+		"allow-my-cluster-healthchecks": "https://www.googleapis.com/compute/v1/projects/my-proj/global/firewalls/allow-my-cluster-healthchecks",
+		"my-cluster-ssh":                "https://www.googleapis.com/compute/v1/projects/my-proj/global/firewalls/my-cluster-ssh",
+	}
+	if d := cmp.Diff(wantStatus, clusterScope.Network().FirewallRules); d != "" {
+		t.Errorf("Service.Reconcile() status firewall rules mismatch (-want +got):\n%s", d)
+	}
+
+	want := []string{"allow-my-cluster-cluster", "allow-my-cluster-healthchecks", "my-cluster-other-ssh", "my-cluster-ssh"}
+	if d := cmp.Diff(want, firewallNames(t, firewalls)); d != "" {
+		t.Errorf("Service.Reconcile() firewalls mismatch (-want +got):\n%s", d)
+	}
+
+	ssh, err := firewalls.Get(ctx, meta.GlobalKey("my-cluster-ssh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSSH := &compute.Firewall{
+		Name:         "my-cluster-ssh",
+		Description:  "capg-cluster-my-cluster",
+		Network:      "projects/my-proj/global/networks/my-network",
+		Direction:    "INGRESS",
+		Priority:     1000,
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"22"}}},
+		SourceRanges: []string{"10.0.0.0/8"},
+		TargetTags:   []string{"my-cluster-node"},
+	}
+	if d := cmp.Diff(wantSSH, ssh, cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".SelfLink" || p.Last().String() == ".ForceSendFields"
+	}, cmp.Ignore())); d != "" {
+		t.Errorf("Service.Reconcile() firewall mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.TODO()
+	updates := []string{}
+	firewalls := newMockFirewalls(&updates)
+	clusterScope := newClusterScope(t)
+	s := &Service{
+		scope:     clusterScope,
+		firewalls: firewalls,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Service.Delete() error = %v", err)
+	}
+
+	want := []string{"my-cluster-other-ssh"}
+	if d := cmp.Diff(want, firewallNames(t, firewalls)); d != "" {
+		t.Errorf("Service.Delete() firewalls mismatch (-want +got):\n%s", d)
+	}
+	if len(clusterScope.Network().FirewallRules) != 0 {
+		t.Errorf("Service.Delete() status firewall rules = %v, want none", clusterScope.Network().FirewallRules)
+	}
+}
+
+func TestFirewallMatches(t *testing.T) {
+	desired := &compute.Firewall{
+		Name:         "allow-my-cluster-healthchecks",
+		Direction:    "INGRESS",
+		Priority:     1000,
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "TCP", Ports: []string{"6443"}}},
+		SourceRanges: []string{"35.191.0.0/16", "130.211.0.0/22"},
+	}
+
+	tests := []struct {
+		name    string
+		current *compute.Firewall
+		want    bool
+	}{
+		{
+			name: "same rule with lower case protocol and reordered ranges",
+			current: &compute.Firewall{
+				Direction:    "INGRESS",
+				Priority:     1000,
+				Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+				SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+			},
+			want: true,
+		},
+		{
+			name: "rule with an additional source range",
+			current: &compute.Firewall{
+				Direction:    "INGRESS",
+				Priority:     1000,
+				Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+				SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16", "0.0.0.0/0"},
+			},
+			want: false,
+		},
+		{
+			name: "disabled rule",
+			current: &compute.Firewall{
+				Direction:    "INGRESS",
+				Priority:     1000,
+				Disabled:     true,
+				Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+				SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := firewallMatches(tt.current, desired); got != tt.want {
+				t.Errorf("firewallMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_Reconcile_iap(t *testing.T) {
+	ctx := context.TODO()
+	updates := []string{}
+	firewalls := newMockFirewalls(&updates)
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.IAP = &infrav1.IAPSpec{APIServer: pointer.Bool(false)}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		Cluster:     fakeCluster,
+		GCPCluster:  gcpCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		scope:     clusterScope,
+		firewalls: firewalls,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	if _, err := firewalls.Get(ctx, meta.GlobalKey("my-cluster-iap-apiserver")); err == nil {
+		t.Errorf("Service.Reconcile() created the disabled IAP API server firewall rule")
+	}
+
+	iap, err := firewalls.Get(ctx, meta.GlobalKey("my-cluster-iap-ssh"))
+	if err != nil {
+		t.Fatalf("Service.Reconcile() IAP SSH firewall rule not created: %v", err)
+	}
+	if d := cmp.Diff([]string{infrav1.IAPSourceRange}, iap.SourceRanges); d != "" {
+		t.Errorf("Service.Reconcile() IAP SSH firewall rule source ranges mismatch (-want +got):\n%s", d)
+	}
+	if d := cmp.Diff([]string{"my-cluster-control-plane", "my-cluster-node"}, iap.TargetTags); d != "" {
+		t.Errorf("Service.Reconcile() IAP SSH firewall rule target tags mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestService_Reconcile_regionalExternal(t *testing.T) {
+	regionalExternal := infrav1.RegionalExternal
+	tests := []struct {
+		name                string
+		allowedSourceRanges []string
+		wantSourceRanges    []string
+	}{
+		{
+			name:             "allowed source ranges not set (should open the API server to the internet)",
+			wantSourceRanges: []string{"0.0.0.0/0"},
+		},
+		{
+			name:                "allowed source ranges set (should restrict the API server to them)",
+			allowedSourceRanges: []string{"203.0.113.0/24", "198.51.100.0/24"},
+			wantSourceRanges:    []string{"203.0.113.0/24", "198.51.100.0/24"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			updates := []string{}
+			firewalls := newMockFirewalls(&updates)
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.LoadBalancer.LoadBalancerType = &regionalExternal
+			gcpCluster.Spec.LoadBalancer.AllowedSourceRanges = tt.allowedSourceRanges
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+				Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+				Cluster:     fakeCluster,
+				GCPCluster:  gcpCluster,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &Service{
+				scope:     clusterScope,
+				firewalls: firewalls,
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			apiserver, err := firewalls.Get(ctx, meta.GlobalKey("my-cluster-apiserver"))
+			if err != nil {
+				t.Fatalf("Service.Reconcile() API server firewall rule not created: %v", err)
+			}
+			if d := cmp.Diff(tt.wantSourceRanges, apiserver.SourceRanges); d != "" {
+				t.Errorf("Service.Reconcile() API server firewall rule source ranges mismatch (-want +got):\n%s", d)
+			}
+
+			healthChecks, err := firewalls.Get(ctx, meta.GlobalKey("allow-my-cluster-healthchecks"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantHealthCheckRanges := []string{"35.191.0.0/16", "130.211.0.0/22", "209.85.152.0/22", "209.85.204.0/22"}
+			if d := cmp.Diff(wantHealthCheckRanges, healthChecks.SourceRanges); d != "" {
+				t.Errorf("Service.Reconcile() health checks firewall rule source ranges mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_Reconcile_internal(t *testing.T) {
+	internal := infrav1.Internal
+	tests := []struct {
+		name                string
+		subnets             infrav1.Subnets
+		allowedSourceRanges []string
+		wantSourceRanges    []string
+	}{
+		{
+			name:             "no subnet declared (should allow the subnetworks of auto mode networks)",
+			wantSourceRanges: []string{"10.128.0.0/9"},
+		},
+		{
+			name:             "subnets declared (should allow them)",
+			subnets:          infrav1.Subnets{{Name: "my-subnet", CidrBlock: "10.0.0.0/20"}, {Name: "my-other-subnet", CidrBlock: "10.0.16.0/20"}},
+			wantSourceRanges: []string{"10.0.0.0/20", "10.0.16.0/20"},
+		},
+		{
+			name:                "allowed source ranges set (should restrict the API server to them)",
+			subnets:             infrav1.Subnets{{Name: "my-subnet", CidrBlock: "10.0.0.0/20"}},
+			allowedSourceRanges: []string{"172.16.0.0/16"},
+			wantSourceRanges:    []string{"172.16.0.0/16"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			updates := []string{}
+			firewalls := newMockFirewalls(&updates)
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.LoadBalancer.LoadBalancerType = &internal
+			gcpCluster.Spec.LoadBalancer.AllowedSourceRanges = tt.allowedSourceRanges
+			gcpCluster.Spec.Network.Subnets = tt.subnets
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+				Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+				Cluster:     fakeCluster,
+				GCPCluster:  gcpCluster,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := &Service{
+				scope:     clusterScope,
+				firewalls: firewalls,
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			apiserver, err := firewalls.Get(ctx, meta.GlobalKey("my-cluster-apiserver"))
+			if err != nil {
+				t.Fatalf("Service.Reconcile() API server firewall rule not created: %v", err)
+			}
+			if d := cmp.Diff(tt.wantSourceRanges, apiserver.SourceRanges); d != "" {
+				t.Errorf("Service.Reconcile() API server firewall rule source ranges mismatch (-want +got):\n%s", d)
+			}
+			if d := cmp.Diff([]string{"my-cluster-control-plane"}, apiserver.TargetTags); d != "" {
+				t.Errorf("Service.Reconcile() API server firewall rule target tags mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_Reconcile_defaultRanges(t *testing.T) {
+	ctx := context.TODO()
+	updates := []string{}
+	firewalls := newMockFirewalls(&updates)
+	egress := infrav1.FirewallRuleDirectionEgress
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.Network.FirewallRules = infrav1.FirewallRules{
+		{Name: "icmp", Protocols: []infrav1.FirewallRuleProtocol{{Protocol: "icmp"}}},
+		{Name: "egress", Direction: &egress, Protocols: []infrav1.FirewallRuleProtocol{{Protocol: "all"}}},
+	}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fake.NewClientBuilder().WithScheme(scheme.Scheme).Build(),
+		Cluster:     fakeCluster,
+		GCPCluster:  gcpCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The rules as read back from GCE, which fills in the default ranges.
+	firewalls.Objects[*meta.GlobalKey("my-cluster-icmp")] = &cloud.MockFirewallsObj{Obj: &compute.Firewall{
+		Name:         "my-cluster-icmp",
+		Description:  infrav1.ClusterTagKey("my-cluster"),
+		Direction:    "INGRESS",
+		Priority:     1000,
+		Allowed:      []*compute.FirewallAllowed{{IPProtocol: "icmp"}},
+		SourceRanges: []string{"0.0.0.0/0"},
+	}}
+	firewalls.Objects[*meta.GlobalKey("my-cluster-egress")] = &cloud.MockFirewallsObj{Obj: &compute.Firewall{
+		Name:              "my-cluster-egress",
+		Description:       infrav1.ClusterTagKey("my-cluster"),
+		Direction:         "EGRESS",
+		Priority:          1000,
+		Allowed:           []*compute.FirewallAllowed{{IPProtocol: "all"}},
+		DestinationRanges: []string{"0.0.0.0/0"},
+	}}
+	s := &Service{
+		scope:     clusterScope,
+		firewalls: firewalls,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	if d := cmp.Diff([]string{"allow-my-cluster-healthchecks"}, updates); d != "" {
+		t.Errorf("Service.Reconcile() updates mismatch (-want +got):\n%s", d)
+	}
+}
@@ -19,6 +19,7 @@ package firewalls
 import (
 	"context"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -26,6 +27,7 @@ import (
 
 type firewallsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Firewall, error)
+	List(ctx context.Context, fl *filter.F) ([]*compute.Firewall, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.Firewall) error
 	Update(ctx context.Context, key *meta.Key, obj *compute.Firewall) error
 	Delete(ctx context.Context, key *meta.Key) error
@@ -35,6 +37,7 @@ type firewallsInterface interface {
 type Scope interface {
 	cloud.ClusterGetter
 	FirewallRulesSpec() []*compute.Firewall
+	FirewallRulePrefix() string
 }
 
 // Service implements firewalls reconciler.
@@ -49,6 +52,6 @@ var _ cloud.Reconciler = &Service{}
 func New(scope Scope) *Service {
 	return &Service{
 		scope:     scope,
-		firewalls: scope.Cloud().Firewalls(),
+		firewalls: scope.NetworkCloud().Firewalls(),
 	}
 }
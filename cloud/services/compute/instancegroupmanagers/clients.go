@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// The instance templates and regional managed instance groups are not part of cloud.Cloud,
+// these clients call the compute service directly and wait for the operations to complete.
+
+// instanceTemplatesClient implements instancetemplatesInterface.
+type instanceTemplatesClient struct {
+	service *compute.Service
+	project string
+}
+
+func (c *instanceTemplatesClient) Get(ctx context.Context, key *meta.Key) (*compute.InstanceTemplate, error) {
+	return c.service.InstanceTemplates.Get(c.project, key.Name).Context(ctx).Do()
+}
+
+// List returns the instance templates named after the prefix followed by the template hashes.
+func (c *instanceTemplatesClient) List(ctx context.Context, prefix string) ([]*compute.InstanceTemplate, error) {
+	var templates []*compute.InstanceTemplate
+	err := c.service.InstanceTemplates.List(c.project).
+		Filter(fmt.Sprintf("name eq %s[0-9a-f]{16}", regexp.QuoteMeta(prefix))).
+		Pages(ctx, func(list *compute.InstanceTemplateList) error {
+			templates = append(templates, list.Items...)
+			return nil
+		})
+
+	return templates, err
+}
+
+func (c *instanceTemplatesClient) Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) error {
+	obj.Name = key.Name
+	op, err := c.service.InstanceTemplates.Insert(c.project, obj).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, op)
+}
+
+func (c *instanceTemplatesClient) Delete(ctx context.Context, key *meta.Key) error {
+	op, err := c.service.InstanceTemplates.Delete(c.project, key.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, op)
+}
+
+func (c *instanceTemplatesClient) wait(ctx context.Context, op *compute.Operation) error {
+	var err error
+	for op.Status != "DONE" {
+		op, err = c.service.GlobalOperations.Wait(c.project, op.Name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+
+	return operationError(op)
+}
+
+// regionInstanceGroupManagersClient implements instancegroupmanagersInterface.
+type regionInstanceGroupManagersClient struct {
+	service *compute.Service
+	project string
+}
+
+func (c *regionInstanceGroupManagersClient) Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroupManager, error) {
+	return c.service.RegionInstanceGroupManagers.Get(c.project, key.Region, key.Name).Context(ctx).Do()
+}
+
+func (c *regionInstanceGroupManagersClient) Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error {
+	obj.Name = key.Name
+	op, err := c.service.RegionInstanceGroupManagers.Insert(c.project, key.Region, obj).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, key, op)
+}
+
+func (c *regionInstanceGroupManagersClient) Patch(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error {
+	op, err := c.service.RegionInstanceGroupManagers.Patch(c.project, key.Region, key.Name, obj).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, key, op)
+}
+
+func (c *regionInstanceGroupManagersClient) Resize(ctx context.Context, key *meta.Key, size int64) error {
+	op, err := c.service.RegionInstanceGroupManagers.Resize(c.project, key.Region, key.Name, size).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, key, op)
+}
+
+func (c *regionInstanceGroupManagersClient) Delete(ctx context.Context, key *meta.Key) error {
+	op, err := c.service.RegionInstanceGroupManagers.Delete(c.project, key.Region, key.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return c.wait(ctx, key, op)
+}
+
+func (c *regionInstanceGroupManagersClient) ListManagedInstances(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error) {
+	var instances []*compute.ManagedInstance
+	err := c.service.RegionInstanceGroupManagers.ListManagedInstances(c.project, key.Region, key.Name).
+		Pages(ctx, func(list *compute.RegionInstanceGroupManagersListInstancesResponse) error {
+			instances = append(instances, list.ManagedInstances...)
+			return nil
+		})
+
+	return instances, err
+}
+
+func (c *regionInstanceGroupManagersClient) wait(ctx context.Context, key *meta.Key, op *compute.Operation) error {
+	var err error
+	for op.Status != "DONE" {
+		op, err = c.service.RegionOperations.Wait(c.project, key.Region, op.Name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+
+	return operationError(op)
+}
+
+// operationError returns the error of a failed operation as a Google API error, so it can be
+// inspected with the gcperrors helpers.
+func operationError(op *compute.Operation) error {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return nil
+	}
+
+	return &googleapi.Error{
+		Code:    int(op.HttpErrorStatusCode),
+		Message: fmt.Sprintf("%s - %s", op.Error.Errors[0].Code, op.Error.Errors[0].Message),
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultMaxSurge is the number of instances created above the target size during a rolling update
+// when the zones of the managed instance group are picked by GCE, which spreads a regional group over 3 zones.
+const defaultMaxSurge = 3
+
+// bootstrapDataHashLength is the length of the hash of the bootstrap data ending the names of the instance
+// templates.
+const bootstrapDataHashLength = 8
+
+// Reconcile reconcile the managed instance group of the machine pool.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling managed instance group resources")
+
+	log.V(2).Info("Getting bootstrap data for machine pool")
+	bootstrapData, err := s.scope.GetBootstrapData()
+	if err != nil {
+		log.Error(err, "Error getting bootstrap data for machine pool")
+		s.scope.MarkConditionFalse(infrav1.BootstrapDataReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, err.Error())
+		return errors.Wrap(err, "failed to retrieve bootstrap data")
+	}
+
+	s.scope.MarkConditionTrue(infrav1.BootstrapDataReadyCondition)
+
+	template, err := s.createOrGetInstanceTemplate(ctx, bootstrapData)
+	if err != nil {
+		s.scope.MarkConditionFalse(infrav1.InstanceTemplateReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceTemplateReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	s.scope.MarkConditionTrue(infrav1.InstanceTemplateReadyCondition)
+	s.scope.SetInstanceTemplate(template.Name)
+
+	instancegroupmanager, err := s.createOrUpdateInstanceGroupManager(ctx, template)
+	if err != nil {
+		s.scope.MarkConditionFalse(infrav1.InstanceGroupReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceGroupReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	instancegroupmanagerKey := meta.RegionalKey(instancegroupmanager.Name, s.scope.Region())
+	managedInstances, err := s.instancegroupmanagers.ListManagedInstances(ctx, instancegroupmanagerKey)
+	if err != nil {
+		log.Error(err, "Error listing managed instances", "name", instancegroupmanager.Name)
+		s.scope.MarkConditionFalse(infrav1.InstanceGroupReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceGroupReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	providerIDs := make([]string, 0, len(managedInstances))
+	running := int32(0)
+	for _, managedInstance := range managedInstances {
+		providerID, ok := providerIDFromInstanceURL(managedInstance.Instance)
+		if !ok {
+			continue
+		}
+
+		providerIDs = append(providerIDs, providerID)
+		if managedInstance.InstanceStatus == string(infrav1.InstanceStatusRunning) {
+			running++
+		}
+	}
+	sort.Strings(providerIDs)
+
+	s.scope.SetProviderIDList(providerIDs)
+	s.scope.SetReplicas(running)
+	s.scope.SetReady()
+
+	if instancegroupmanager.Status != nil && instancegroupmanager.Status.IsStable {
+		s.scope.MarkConditionTrue(infrav1.InstanceGroupReadyCondition)
+	} else {
+		s.scope.MarkConditionFalse(infrav1.InstanceGroupReadyCondition, infrav1.InstanceGroupUpdatingReason, clusterv1.ConditionSeverityInfo,
+			fmt.Sprintf("%d of %d instances running", running, instancegroupmanager.TargetSize))
+	}
+
+	s.deleteUnusedInstanceTemplates(ctx, template.Name)
+
+	return nil
+}
+
+// Delete delete the managed instance group and the instance templates of the machine pool.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting managed instance group resources")
+
+	name := s.scope.InstanceGroupName()
+	instancegroupmanagerKey := meta.RegionalKey(name, s.scope.Region())
+	log.V(2).Info("Looking for managed instance group before deleting", "name", name)
+	_, err := s.instancegroupmanagers.Get(ctx, instancegroupmanagerKey)
+	if err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error looking for managed instance group before deleting", "name", name)
+		return err
+	}
+
+	if err == nil {
+		log.V(2).Info("Deleting managed instance group", "name", name)
+		if err := gcperrors.IgnoreNotFound(s.instancegroupmanagers.Delete(ctx, instancegroupmanagerKey)); err != nil {
+			log.Error(err, "Error deleting managed instance group", "name", name)
+			s.scope.MarkConditionFalse(infrav1.InstanceGroupReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+	}
+
+	s.scope.MarkConditionFalse(infrav1.InstanceGroupReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	templates, err := s.listInstanceTemplates(ctx)
+	if err != nil {
+		log.Error(err, "Error listing instance templates before deleting")
+		return err
+	}
+
+	for _, template := range templates {
+		log.V(2).Info("Deleting instance template", "name", template.Name)
+		if err := gcperrors.IgnoreNotFound(s.instancetemplates.Delete(ctx, meta.GlobalKey(template.Name))); err != nil {
+			log.Error(err, "Error deleting instance template", "name", template.Name)
+			s.scope.MarkConditionFalse(infrav1.InstanceTemplateReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+	}
+
+	s.scope.MarkConditionFalse(infrav1.InstanceTemplateReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+
+	return nil
+}
+
+func (s *Service) createOrGetInstanceTemplate(ctx context.Context, bootstrapData string) (*compute.InstanceTemplate, error) {
+	log := log.FromContext(ctx)
+	templateSpec, err := s.scope.InstanceTemplateSpec(bootstrapData)
+	if err != nil {
+		log.Error(err, "Error building instance template")
+		return nil, err
+	}
+
+	templateKey := meta.GlobalKey(templateSpec.Name)
+	log.V(2).Info("Looking for instance template", "name", templateSpec.Name)
+	template, err := s.instancetemplates.Get(ctx, templateKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for instance template", "name", templateSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an instance template", "name", templateSpec.Name)
+		if err := s.instancetemplates.Insert(ctx, templateKey, templateSpec); err != nil {
+			log.Error(err, "Error creating an instance template", "name", templateSpec.Name)
+			return nil, err
+		}
+
+		template, err = s.instancetemplates.Get(ctx, templateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
+
+func (s *Service) createOrUpdateInstanceGroupManager(ctx context.Context, template *compute.InstanceTemplate) (*compute.InstanceGroupManager, error) {
+	log := log.FromContext(ctx)
+	instancegroupmanagerSpec := s.instanceGroupManagerSpec(template)
+	instancegroupmanagerKey := meta.RegionalKey(instancegroupmanagerSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for managed instance group", "name", instancegroupmanagerSpec.Name)
+	instancegroupmanager, err := s.instancegroupmanagers.Get(ctx, instancegroupmanagerKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for managed instance group", "name", instancegroupmanagerSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a managed instance group", "name", instancegroupmanagerSpec.Name)
+		if err := s.instancegroupmanagers.Insert(ctx, instancegroupmanagerKey, instancegroupmanagerSpec); err != nil {
+			log.Error(err, "Error creating a managed instance group", "name", instancegroupmanagerSpec.Name)
+			return nil, err
+		}
+
+		return s.instancegroupmanagers.Get(ctx, instancegroupmanagerKey)
+	}
+
+	updated := false
+	if instancegroupmanager.InstanceTemplate != template.SelfLink {
+		// The proactive update policy rolls the instances to the new instance template. A change of the bootstrap
+		// data alone, such as a rotated bootstrap token, must not replace the running instances: the opportunistic
+		// update policy only applies the new template to the instances created from now on.
+		updatePolicy := instancegroupmanagerSpec.UpdatePolicy
+		if bootstrapDataChangedOnly(path.Base(instancegroupmanager.InstanceTemplate), template.Name) {
+			updatePolicy.Type = "OPPORTUNISTIC"
+		}
+
+		log.V(2).Info("Updating the instance template of the managed instance group", "name", instancegroupmanagerSpec.Name, "template", template.Name, "policy", updatePolicy.Type)
+		if err := s.instancegroupmanagers.Patch(ctx, instancegroupmanagerKey, &compute.InstanceGroupManager{
+			InstanceTemplate: instancegroupmanagerSpec.InstanceTemplate,
+			UpdatePolicy:     updatePolicy,
+		}); err != nil {
+			log.Error(err, "Error updating the instance template of the managed instance group", "name", instancegroupmanagerSpec.Name)
+			return nil, err
+		}
+		updated = true
+	}
+
+	if instancegroupmanager.TargetSize != instancegroupmanagerSpec.TargetSize {
+		log.V(2).Info("Resizing the managed instance group", "name", instancegroupmanagerSpec.Name, "size", instancegroupmanagerSpec.TargetSize)
+		if err := s.instancegroupmanagers.Resize(ctx, instancegroupmanagerKey, instancegroupmanagerSpec.TargetSize); err != nil {
+			log.Error(err, "Error resizing the managed instance group", "name", instancegroupmanagerSpec.Name)
+			return nil, err
+		}
+		updated = true
+	}
+
+	if updated {
+		return s.instancegroupmanagers.Get(ctx, instancegroupmanagerKey)
+	}
+
+	return instancegroupmanager, nil
+}
+
+// instanceGroupManagerSpec returns the regional managed instance group of the machine pool.
+func (s *Service) instanceGroupManagerSpec(template *compute.InstanceTemplate) *compute.InstanceGroupManager {
+	maxSurge := int64(defaultMaxSurge)
+	var distributionPolicy *compute.DistributionPolicy
+	if zones := s.scope.Zones(); len(zones) > 0 {
+		// The maximum surge of a regional managed instance group must be at least its number of zones.
+		maxSurge = int64(len(zones))
+		distributionPolicy = &compute.DistributionPolicy{}
+		for _, zone := range zones {
+			distributionPolicy.Zones = append(distributionPolicy.Zones, &compute.DistributionPolicyZoneConfiguration{
+				Zone: path.Join("zones", zone),
+			})
+		}
+	}
+
+	return &compute.InstanceGroupManager{
+		Name:               s.scope.InstanceGroupName(),
+		BaseInstanceName:   s.scope.InstanceGroupName(),
+		InstanceTemplate:   template.SelfLink,
+		TargetSize:         s.scope.Replicas(),
+		DistributionPolicy: distributionPolicy,
+		UpdatePolicy: &compute.InstanceGroupManagerUpdatePolicy{
+			Type:                       "PROACTIVE",
+			MinimalAction:              "REPLACE",
+			InstanceRedistributionType: "PROACTIVE",
+			MaxSurge:                   &compute.FixedOrPercent{Fixed: maxSurge},
+			MaxUnavailable:             &compute.FixedOrPercent{Fixed: 0, ForceSendFields: []string{"Fixed"}},
+		},
+		ForceSendFields: []string{"TargetSize"},
+	}
+}
+
+// deleteUnusedInstanceTemplates deletes the instance templates of the machine pool which are not used anymore.
+// Failures are only logged, the templates are deleted at the next reconciliation or with the machine pool.
+func (s *Service) deleteUnusedInstanceTemplates(ctx context.Context, current string) {
+	log := log.FromContext(ctx)
+	templates, err := s.listInstanceTemplates(ctx)
+	if err != nil {
+		log.Error(err, "Error listing instance templates")
+		return
+	}
+
+	for _, template := range templates {
+		if template.Name == current {
+			continue
+		}
+
+		log.V(2).Info("Deleting unused instance template", "name", template.Name)
+		if err := gcperrors.IgnoreNotFound(s.instancetemplates.Delete(ctx, meta.GlobalKey(template.Name))); err != nil {
+			log.V(2).Info("Unable to delete unused instance template", "name", template.Name, "reason", err.Error())
+		}
+	}
+}
+
+// bootstrapDataChangedOnly returns whether the instance templates only differ by the hash of their bootstrap data.
+func bootstrapDataChangedOnly(current, template string) bool {
+	if len(current) != len(template) || len(template) < bootstrapDataHashLength {
+		return false
+	}
+
+	return current[:len(current)-bootstrapDataHashLength] == template[:len(template)-bootstrapDataHashLength]
+}
+
+// listInstanceTemplates returns the instance templates of the machine pool: the ones named after its prefix and
+// owned by its cluster.
+func (s *Service) listInstanceTemplates(ctx context.Context) ([]*compute.InstanceTemplate, error) {
+	templates, err := s.instancetemplates.List(ctx, s.scope.InstanceTemplatePrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]*compute.InstanceTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template.Description == s.scope.InstanceTemplateDescription() {
+			owned = append(owned, template)
+		}
+	}
+
+	return owned, nil
+}
+
+// providerIDFromInstanceURL returns the provider ID of the instance referenced by its URL,
+// e.g. https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-instance.
+func providerIDFromInstanceURL(instanceURL string) (string, bool) {
+	parts := strings.Split(instanceURL, "/")
+	if len(parts) < 6 || parts[len(parts)-6] != "projects" || parts[len(parts)-4] != "zones" || parts[len(parts)-2] != "instances" {
+		return "", false
+	}
+
+	return fmt.Sprintf("gce://%s/%s/%s", parts[len(parts)-5], parts[len(parts)-3], parts[len(parts)-1]), true
+}
@@ -0,0 +1,459 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = expclusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeBootstrapSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool-bootstrap",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"value": []byte("Zm9vCg=="),
+	},
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+	Status: infrav1.GCPClusterStatus{
+		FailureDomains: clusterv1.FailureDomains{
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+			"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+		},
+	},
+}
+
+var fakeMachinePool = &expclusterv1.MachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: expclusterv1.MachinePoolSpec{
+		ClusterName: "my-cluster",
+		Replicas:    pointer.Int32(2),
+		Template: clusterv1.MachineTemplateSpec{
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{
+					DataSecretName: pointer.String("my-pool-bootstrap"),
+				},
+				Version: pointer.String("v1.19.11"),
+			},
+		},
+	},
+}
+
+var fakeGCPMachinePool = &infrav1.GCPMachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPMachinePoolSpec{
+		Template: infrav1.GCPMachineSpec{
+			InstanceType: "n1-standard-2",
+		},
+	},
+}
+
+// fakeInstanceTemplates is an in-memory instancetemplatesInterface.
+type fakeInstanceTemplates struct {
+	templates map[string]*compute.InstanceTemplate
+}
+
+func (f *fakeInstanceTemplates) Get(_ context.Context, key *meta.Key) (*compute.InstanceTemplate, error) {
+	template, ok := f.templates[key.Name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return template, nil
+}
+
+func (f *fakeInstanceTemplates) List(_ context.Context, prefix string) ([]*compute.InstanceTemplate, error) {
+	var templates []*compute.InstanceTemplate
+	filter := regexp.MustCompile("^" + regexp.QuoteMeta(prefix) + "[0-9a-f]{16}$")
+	for name, template := range f.templates {
+		if filter.MatchString(name) {
+			templates = append(templates, template)
+		}
+	}
+
+	return templates, nil
+}
+
+func (f *fakeInstanceTemplates) Insert(_ context.Context, key *meta.Key, obj *compute.InstanceTemplate) error {
+	obj.Name = key.Name
+	obj.SelfLink = instanceTemplateSelfLink(key.Name)
+	f.templates[key.Name] = obj
+	return nil
+}
+
+func (f *fakeInstanceTemplates) Delete(_ context.Context, key *meta.Key) error {
+	if _, ok := f.templates[key.Name]; !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	delete(f.templates, key.Name)
+	return nil
+}
+
+// fakeInstanceGroupManagers is an in-memory instancegroupmanagersInterface.
+type fakeInstanceGroupManagers struct {
+	instancegroupmanagers map[string]*compute.InstanceGroupManager
+	managedInstances      []*compute.ManagedInstance
+}
+
+func (f *fakeInstanceGroupManagers) Get(_ context.Context, key *meta.Key) (*compute.InstanceGroupManager, error) {
+	instancegroupmanager, ok := f.instancegroupmanagers[key.Name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return instancegroupmanager, nil
+}
+
+func (f *fakeInstanceGroupManagers) Insert(_ context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error {
+	obj.Name = key.Name
+	obj.Status = &compute.InstanceGroupManagerStatus{IsStable: false}
+	f.instancegroupmanagers[key.Name] = obj
+	return nil
+}
+
+func (f *fakeInstanceGroupManagers) Patch(_ context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error {
+	instancegroupmanager, ok := f.instancegroupmanagers[key.Name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	instancegroupmanager.InstanceTemplate = obj.InstanceTemplate
+	instancegroupmanager.UpdatePolicy = obj.UpdatePolicy
+	instancegroupmanager.Status = &compute.InstanceGroupManagerStatus{IsStable: false}
+	return nil
+}
+
+func (f *fakeInstanceGroupManagers) Resize(_ context.Context, key *meta.Key, size int64) error {
+	instancegroupmanager, ok := f.instancegroupmanagers[key.Name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	instancegroupmanager.TargetSize = size
+	instancegroupmanager.Status = &compute.InstanceGroupManagerStatus{IsStable: false}
+	return nil
+}
+
+func (f *fakeInstanceGroupManagers) Delete(_ context.Context, key *meta.Key) error {
+	if _, ok := f.instancegroupmanagers[key.Name]; !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	delete(f.instancegroupmanagers, key.Name)
+	return nil
+}
+
+func (f *fakeInstanceGroupManagers) ListManagedInstances(_ context.Context, _ *meta.Key) ([]*compute.ManagedInstance, error) {
+	return f.managedInstances, nil
+}
+
+// instanceGroupName is the name of the managed instance group of fakeGCPMachinePool.
+const instanceGroupName = "my-pool-9580aabe"
+
+func instanceTemplateSelfLink(name string) string {
+	return "https://www.googleapis.com/compute/v1/projects/my-proj/global/instanceTemplates/" + name
+}
+
+func newMachinePoolScope(t *testing.T) *scope.MachinePoolScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster.DeepCopy(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:         fakec,
+		ClusterGetter:  clusterScope,
+		Cluster:        fakeCluster,
+		MachinePool:    fakeMachinePool.DeepCopy(),
+		GCPMachinePool: fakeGCPMachinePool.DeepCopy(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return machinePoolScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	managedInstances := []*compute.ManagedInstance{
+		{
+			Instance:       "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-b/instances/my-pool-xyz1",
+			InstanceStatus: "PROVISIONING",
+		},
+		{
+			Instance:       "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-abc1",
+			InstanceStatus: "RUNNING",
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		templates             map[string]*compute.InstanceTemplate
+		instancegroupmanagers map[string]*compute.InstanceGroupManager
+		// bootstrapDataChanged makes the managed instance group use the current template with other bootstrap data.
+		bootstrapDataChanged bool
+		wantTemplates        int
+		wantTargetSize       int64
+		wantUpdatePolicy     string
+		wantGroupReady       bool
+	}{
+		{
+			name:                  "instance template and managed instance group do not exist (should create them)",
+			templates:             map[string]*compute.InstanceTemplate{},
+			instancegroupmanagers: map[string]*compute.InstanceGroupManager{},
+			wantTemplates:         1,
+			wantTargetSize:        2,
+			wantUpdatePolicy:      "PROACTIVE",
+			wantGroupReady:        false,
+		},
+		{
+			name: "instance template changed (should roll the managed instance group and delete the unused template)",
+			templates: map[string]*compute.InstanceTemplate{
+				"my-pool-9580aabe-0000000000000000": {
+					Name:        "my-pool-9580aabe-0000000000000000",
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					SelfLink:    instanceTemplateSelfLink("my-pool-9580aabe-0000000000000000"),
+				},
+				"my-pool-9580aabe-1111111111111111": {
+					Name:     "my-pool-9580aabe-1111111111111111",
+					SelfLink: instanceTemplateSelfLink("my-pool-9580aabe-1111111111111111"),
+				},
+				"my-pool-a-0000000000000000": {
+					Name:        "my-pool-a-0000000000000000",
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					SelfLink:    instanceTemplateSelfLink("my-pool-a-0000000000000000"),
+				},
+			},
+			instancegroupmanagers: map[string]*compute.InstanceGroupManager{
+				instanceGroupName: {
+					Name:             instanceGroupName,
+					InstanceTemplate: instanceTemplateSelfLink("my-pool-9580aabe-0000000000000000"),
+					TargetSize:       2,
+					Status:           &compute.InstanceGroupManagerStatus{IsStable: true},
+				},
+			},
+			// The template not owned by the cluster and the template of the other pool are kept.
+			wantTemplates:    3,
+			wantTargetSize:   2,
+			wantUpdatePolicy: "PROACTIVE",
+			wantGroupReady:   false,
+		},
+		{
+			name:      "bootstrap data changed (should update the managed instance group without rolling it)",
+			templates: map[string]*compute.InstanceTemplate{},
+			instancegroupmanagers: map[string]*compute.InstanceGroupManager{
+				instanceGroupName: {
+					Name:       instanceGroupName,
+					TargetSize: 2,
+					Status:     &compute.InstanceGroupManagerStatus{IsStable: true},
+				},
+			},
+			bootstrapDataChanged: true,
+			wantTemplates:        1,
+			wantTargetSize:       2,
+			wantUpdatePolicy:     "OPPORTUNISTIC",
+			wantGroupReady:       false,
+		},
+		{
+			name:      "managed instance group up to date (should be ready)",
+			templates: map[string]*compute.InstanceTemplate{},
+			instancegroupmanagers: map[string]*compute.InstanceGroupManager{
+				instanceGroupName: {
+					Name:       instanceGroupName,
+					TargetSize: 2,
+					Status:     &compute.InstanceGroupManagerStatus{IsStable: true},
+				},
+			},
+			wantTemplates:  1,
+			wantTargetSize: 2,
+			wantGroupReady: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			machinePoolScope := newMachinePoolScope(t)
+
+			template, err := machinePoolScope.InstanceTemplateSpec("Zm9vCg==")
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The up to date managed instance group already uses the current template.
+			if igm, ok := tt.instancegroupmanagers[instanceGroupName]; ok && igm.InstanceTemplate == "" {
+				name := template.Name
+				if tt.bootstrapDataChanged {
+					name = name[:len(name)-bootstrapDataHashLength] + "00000000"
+				}
+				tt.templates[name] = &compute.InstanceTemplate{
+					Name:        name,
+					Description: infrav1.ClusterTagKey("my-cluster"),
+					SelfLink:    instanceTemplateSelfLink(name),
+				}
+				igm.InstanceTemplate = instanceTemplateSelfLink(name)
+			}
+
+			templates := &fakeInstanceTemplates{templates: tt.templates}
+			instancegroupmanagers := &fakeInstanceGroupManagers{
+				instancegroupmanagers: tt.instancegroupmanagers,
+				managedInstances:      managedInstances,
+			}
+			s := &Service{
+				scope:                 machinePoolScope,
+				instancetemplates:     templates,
+				instancegroupmanagers: instancegroupmanagers,
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			if _, ok := templates.templates[template.Name]; !ok || len(templates.templates) != tt.wantTemplates {
+				t.Errorf("Service.Reconcile() templates = %v, want %d templates including %s", keys(templates.templates), tt.wantTemplates, template.Name)
+			}
+
+			igm := instancegroupmanagers.instancegroupmanagers[instanceGroupName]
+			if igm.InstanceTemplate != instanceTemplateSelfLink(template.Name) {
+				t.Errorf("Service.Reconcile() instance template = %s, want %s", igm.InstanceTemplate, instanceTemplateSelfLink(template.Name))
+			}
+			if igm.TargetSize != tt.wantTargetSize {
+				t.Errorf("Service.Reconcile() target size = %d, want %d", igm.TargetSize, tt.wantTargetSize)
+			}
+			if tt.wantUpdatePolicy != "" && (igm.UpdatePolicy == nil || igm.UpdatePolicy.Type != tt.wantUpdatePolicy) {
+				t.Errorf("Service.Reconcile() update policy = %v, want %s", igm.UpdatePolicy, tt.wantUpdatePolicy)
+			}
+
+			gcpMachinePool := machinePoolScope.GCPMachinePool
+			wantProviderIDs := []string{
+				"gce://my-proj/us-central1-a/my-pool-abc1",
+				"gce://my-proj/us-central1-b/my-pool-xyz1",
+			}
+			if d := cmp.Diff(wantProviderIDs, gcpMachinePool.Spec.ProviderIDList); d != "" {
+				t.Errorf("Service.Reconcile() ProviderIDList mismatch (-want +got):\n%s", d)
+			}
+			if gcpMachinePool.Status.Replicas != 1 {
+				t.Errorf("Service.Reconcile() Replicas = %d, want 1", gcpMachinePool.Status.Replicas)
+			}
+			if gcpMachinePool.Status.InstanceTemplate != template.Name {
+				t.Errorf("Service.Reconcile() InstanceTemplate = %s, want %s", gcpMachinePool.Status.InstanceTemplate, template.Name)
+			}
+			if got := conditions.IsTrue(gcpMachinePool, infrav1.InstanceGroupReadyCondition); got != tt.wantGroupReady {
+				t.Errorf("Service.Reconcile() InstanceGroupReady = %v, want %v", got, tt.wantGroupReady)
+			}
+		})
+	}
+}
+
+func TestService_instanceGroupManagerSpec(t *testing.T) {
+	s := &Service{scope: newMachinePoolScope(t)}
+	template := &compute.InstanceTemplate{Name: "my-pool-9580aabe-abcdef0123456789", SelfLink: instanceTemplateSelfLink("my-pool-9580aabe-abcdef0123456789")}
+
+	want := &compute.InstanceGroupManager{
+		Name:             instanceGroupName,
+		BaseInstanceName: instanceGroupName,
+		InstanceTemplate: instanceTemplateSelfLink("my-pool-9580aabe-abcdef0123456789"),
+		TargetSize:       2,
+		DistributionPolicy: &compute.DistributionPolicy{
+			Zones: []*compute.DistributionPolicyZoneConfiguration{
+				{Zone: "zones/us-central1-a"},
+				{Zone: "zones/us-central1-b"},
+			},
+		},
+		UpdatePolicy: &compute.InstanceGroupManagerUpdatePolicy{
+			Type:                       "PROACTIVE",
+			MinimalAction:              "REPLACE",
+			InstanceRedistributionType: "PROACTIVE",
+			MaxSurge:                   &compute.FixedOrPercent{Fixed: 2},
+			MaxUnavailable:             &compute.FixedOrPercent{Fixed: 0, ForceSendFields: []string{"Fixed"}},
+		},
+		ForceSendFields: []string{"TargetSize"},
+	}
+	if d := cmp.Diff(want, s.instanceGroupManagerSpec(template)); d != "" {
+		t.Errorf("Service.instanceGroupManagerSpec() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func keys(templates map[string]*compute.InstanceTemplate) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
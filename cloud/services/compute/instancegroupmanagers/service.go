@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type instancetemplatesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceTemplate, error)
+	List(ctx context.Context, prefix string) ([]*compute.InstanceTemplate, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type instancegroupmanagersInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroupManager, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error
+	Patch(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error
+	Resize(ctx context.Context, key *meta.Key, size int64) error
+	Delete(ctx context.Context, key *meta.Key) error
+	ListManagedInstances(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.MachinePool
+}
+
+// Service implements managed instance groups reconciler.
+type Service struct {
+	scope                 Scope
+	instancetemplates     instancetemplatesInterface
+	instancegroupmanagers instancegroupmanagersInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope: scope,
+		instancetemplates: &instanceTemplatesClient{
+			service: scope.ComputeService(),
+			project: scope.Project(),
+		},
+		instancegroupmanagers: &regionInstanceGroupManagersClient{
+			service: scope.ComputeService(),
+			project: scope.Project(),
+		},
+	}
+}
@@ -19,6 +19,7 @@ package instances
 import (
 	"context"
 	"fmt"
+	"path"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -41,6 +43,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if instance == nil {
+		// The instance was reclaimed and deleted by GCE.
+		return nil
+	}
+
 	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
 	for _, iface := range instance.NetworkInterfaces {
 		addresses = append(addresses, corev1.NodeAddress{
@@ -83,10 +90,15 @@ func (s *Service) Reconcile(ctx context.Context) error {
 
 	s.scope.SetProviderID()
 	s.scope.SetAddresses(addresses)
+	s.scope.SetAccelerators(acceleratorsFromInstance(instance))
+	s.scope.SetShieldedInstanceConfig(shieldedInstanceConfigFromInstance(instance))
+	s.scope.SetConfidentialCompute(confidentialComputeFromInstance(instance))
 	s.scope.SetInstanceStatus(infrav1.InstanceStatus(instance.Status))
+	s.setInstanceReadyCondition(infrav1.InstanceStatus(instance.Status))
 
-	if s.scope.IsControlPlane() {
+	if s.scope.IsControlPlaneLoadBalanced() {
 		if err := s.registerControlPlaneInstance(ctx, instance); err != nil {
+			s.scope.MarkConditionFalse(infrav1.ControlPlaneLBRegisteredCondition, gcperrors.ReasonForError(err, infrav1.ControlPlaneLBRegistrationFailedReason), clusterv1.ConditionSeverityError, err.Error())
 			return err
 		}
 	}
@@ -94,6 +106,76 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// acceleratorsFromInstance returns the accelerator cards attached to the instance.
+func acceleratorsFromInstance(instance *compute.Instance) []infrav1.Accelerator {
+	var accelerators []infrav1.Accelerator
+	for _, accelerator := range instance.GuestAccelerators {
+		accelerators = append(accelerators, infrav1.Accelerator{
+			Type:  path.Base(accelerator.AcceleratorType),
+			Count: accelerator.AcceleratorCount,
+		})
+	}
+
+	return accelerators
+}
+
+// shieldedInstanceConfigFromInstance returns the effective Shielded VM configuration of the instance.
+func shieldedInstanceConfigFromInstance(instance *compute.Instance) *infrav1.GCPShieldedInstanceConfig {
+	if instance.ShieldedInstanceConfig == nil {
+		return nil
+	}
+
+	config := &infrav1.GCPShieldedInstanceConfig{
+		SecureBoot:                       infrav1.SecureBootPolicyDisabled,
+		VirtualizedTrustedPlatformModule: infrav1.VirtualizedTrustedPlatformModulePolicyDisabled,
+		IntegrityMonitoring:              infrav1.IntegrityMonitoringPolicyDisabled,
+	}
+	if instance.ShieldedInstanceConfig.EnableSecureBoot {
+		config.SecureBoot = infrav1.SecureBootPolicyEnabled
+	}
+	if instance.ShieldedInstanceConfig.EnableVtpm {
+		config.VirtualizedTrustedPlatformModule = infrav1.VirtualizedTrustedPlatformModulePolicyEnabled
+	}
+	if instance.ShieldedInstanceConfig.EnableIntegrityMonitoring {
+		config.IntegrityMonitoring = infrav1.IntegrityMonitoringPolicyEnabled
+	}
+
+	return config
+}
+
+// confidentialComputeFromInstance returns the effective confidential compute configuration of the instance.
+func confidentialComputeFromInstance(instance *compute.Instance) *infrav1.ConfidentialComputePolicy {
+	policy := infrav1.ConfidentialComputePolicyDisabled
+	if instance.ConfidentialInstanceConfig != nil && instance.ConfidentialInstanceConfig.EnableConfidentialCompute {
+		policy = infrav1.ConfidentialComputePolicyEnabled
+	}
+
+	return &policy
+}
+
+// setInstanceReadyCondition sets the InstanceReady condition from the state of the instance. Stopped Spot and
+// preemptible instances were reclaimed by GCE, they keep the InstancePreempted reason until they are remediated.
+func (s *Service) setInstanceReadyCondition(status infrav1.InstanceStatus) {
+	stopped := status == infrav1.InstanceStatusStopping || status == infrav1.InstanceStatusStopped || status == infrav1.InstanceStatusTerminated
+	if stopped && s.scope.IsPreemptible() {
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstancePreemptedReason, clusterv1.ConditionSeverityWarning, fmt.Sprintf("instance is %s", status))
+		return
+	}
+
+	switch status {
+	case infrav1.InstanceStatusRunning:
+		s.scope.MarkConditionTrue(infrav1.InstanceReadyCondition)
+	case infrav1.InstanceStatusProvisioning, infrav1.InstanceStatusStaging:
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityInfo, fmt.Sprintf("instance is %s", status))
+	case infrav1.InstanceStatusRepairing:
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstanceNotReadyReason, clusterv1.ConditionSeverityWarning, fmt.Sprintf("instance is %s", status))
+	case infrav1.InstanceStatusTerminated:
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstanceTerminatedReason, clusterv1.ConditionSeverityError, fmt.Sprintf("instance is %s", status))
+	default:
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstanceStoppedReason, clusterv1.ConditionSeverityError, fmt.Sprintf("instance is %s", status))
+	}
+}
+
 // Delete delete machine instance.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
@@ -112,14 +194,23 @@ func (s *Service) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	if s.scope.IsControlPlane() {
+	if s.scope.IsControlPlaneLoadBalanced() {
 		if err := s.deregisterControlPlaneInstance(ctx, instance); err != nil {
+			s.scope.MarkConditionFalse(infrav1.ControlPlaneLBRegisteredCondition, gcperrors.ReasonForError(err, infrav1.ControlPlaneLBDeregistrationFailedReason), clusterv1.ConditionSeverityWarning, err.Error())
 			return err
 		}
+
+		s.scope.MarkConditionFalse(infrav1.ControlPlaneLBRegisteredCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	}
 
 	log.V(2).Info("Deleting instance", "name", instanceName, "zone", s.scope.Zone())
-	return gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey))
+	if err := s.instances.Delete(ctx, instanceKey); err != nil && !gcperrors.IsNotFound(err) {
+		s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, gcperrors.ReasonForError(err, clusterv1.DeletionFailedReason), clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
 }
 
 func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
@@ -128,9 +219,12 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 	bootstrapData, err := s.scope.GetBootstrapData()
 	if err != nil {
 		log.Error(err, "Error getting bootstrap data for machine")
+		s.scope.MarkConditionFalse(infrav1.BootstrapDataReadyCondition, infrav1.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, err.Error())
 		return nil, errors.Wrap(err, "failed to retrieve bootstrap data")
 	}
 
+	s.scope.MarkConditionTrue(infrav1.BootstrapDataReadyCondition)
+
 	instanceSpec := s.scope.InstanceSpec()
 	instanceName := instanceSpec.Name
 	instanceKey := meta.ZonalKey(instanceName, s.scope.Zone())
@@ -144,17 +238,41 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 	if err != nil {
 		if !gcperrors.IsNotFound(err) {
 			log.Error(err, "Error looking for instance", "name", instanceName, "zone", s.scope.Zone())
+			s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceNotFoundReason), clusterv1.ConditionSeverityError, err.Error())
 			return nil, err
 		}
 
+		// A Spot or preemptible instance created before and missing now was deleted by GCE when reclaimed, e.g.
+		// with the Delete termination action. It is not recreated under the same machine: the machine is left to
+		// the MachineHealthCheck remediation, like a reclaimed instance which was stopped.
+		if s.scope.GetProviderID() != "" && s.scope.IsPreemptible() {
+			log.Info("Instance was reclaimed and deleted", "name", instanceName, "zone", s.scope.Zone())
+			s.scope.SetInstanceStatus(infrav1.InstanceStatusTerminated)
+			s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstancePreemptedReason, clusterv1.ConditionSeverityWarning, "instance was deleted")
+			return nil, nil
+		}
+
+		encryptionKeys, err := s.scope.InstanceDiskEncryptionKeysSpec()
+		if err != nil {
+			log.Error(err, "Error getting disk encryption keys", "name", instanceName)
+			s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, infrav1.InstanceProvisionFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return nil, err
+		}
+
+		for i, key := range encryptionKeys {
+			instanceSpec.Disks[i].DiskEncryptionKey = key
+		}
+
 		log.V(2).Info("Creating an instance", "name", instanceName, "zone", s.scope.Zone())
 		if err := s.instances.Insert(ctx, instanceKey, instanceSpec); err != nil {
 			log.Error(err, "Error creating an instance", "name", instanceName, "zone", s.scope.Zone())
+			s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceProvisionFailedReason), clusterv1.ConditionSeverityError, err.Error())
 			return nil, err
 		}
 
 		instance, err = s.instances.Get(ctx, instanceKey)
 		if err != nil {
+			s.scope.MarkConditionFalse(infrav1.InstanceReadyCondition, gcperrors.ReasonForError(err, infrav1.InstanceNotFoundReason), clusterv1.ConditionSeverityError, err.Error())
 			return nil, err
 		}
 	}
@@ -181,7 +299,12 @@ func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *co
 		instanceSets.Insert(i.Instance)
 	}
 
-	if !instanceSets.Has(instance.SelfLink) && instance.Status == string(infrav1.InstanceStatusRunning) {
+	if instance.Status != string(infrav1.InstanceStatusRunning) {
+		s.scope.MarkConditionFalse(infrav1.ControlPlaneLBRegisteredCondition, infrav1.WaitingForInstanceRunningReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	if !instanceSets.Has(instance.SelfLink) {
 		log.V(2).Info("Registering instance in the instancegroup", "name", instance.Name, "instancegroup", instancegroupName)
 		if err := s.instancegroups.AddInstances(ctx, instancegroupKey, &compute.InstanceGroupsAddInstancesRequest{
 			Instances: []*compute.InstanceReference{
@@ -194,6 +317,7 @@ func (s *Service) registerControlPlaneInstance(ctx context.Context, instance *co
 		}
 	}
 
+	s.scope.MarkConditionTrue(infrav1.ControlPlaneLBRegisteredCondition)
 	return nil
 }
 
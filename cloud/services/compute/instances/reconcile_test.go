@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/compute/v1"
@@ -33,6 +34,7 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -51,6 +53,16 @@ var fakeBootstrapSecret = &corev1.Secret{
 	},
 }
 
+var fakeDiskEncryptionKeySecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-disk-key",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"key": []byte("SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0=\n"),
+	},
+}
+
 var fakeCluster = &clusterv1.Cluster{
 	ObjectMeta: metav1.ObjectMeta{
 		Name:      "my-cluster",
@@ -115,6 +127,20 @@ var fakeGCPCluster = &infrav1.GCPCluster{
 	},
 }
 
+var fakeGCPClusterWithSharedVpc = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			HostProject: pointer.String("my-host-proj"),
+		},
+	},
+}
+
 var fakeGCPMachine = &infrav1.GCPMachine{
 	ObjectMeta: metav1.ObjectMeta{
 		Name:      "my-machine",
@@ -130,13 +156,14 @@ var fakeGCPMachine = &infrav1.GCPMachine{
 func TestService_createOrGetInstance(t *testing.T) {
 	fakec := fake.NewClientBuilder().
 		WithScheme(scheme.Scheme).
-		WithObjects(fakeBootstrapSecret).
+		WithObjects(fakeBootstrapSecret, fakeDiskEncryptionKeySecret).
 		Build()
 
 	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:     fakec,
-		Cluster:    fakeCluster,
-		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -153,9 +180,10 @@ func TestService_createOrGetInstance(t *testing.T) {
 	}
 
 	clusterScopeWithoutFailureDomain, err := scope.NewClusterScope(scope.ClusterScopeParams{
-		Client:     fakec,
-		Cluster:    fakeCluster,
-		GCPCluster: fakeGCPClusterWithOutFailureDomain,
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPClusterWithOutFailureDomain,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -171,6 +199,100 @@ func TestService_createOrGetInstance(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	clusterScopeWithSharedVpc, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPClusterWithSharedVpc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScopeWithSharedVpc, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScopeWithSharedVpc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	machineScopeWithSharedVpc.GCPMachine.Spec.Subnet = pointer.String("my-subnet")
+
+	machineScopeWithSpot, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spot := infrav1.ProvisioningModelSpot
+	terminationActionDelete := infrav1.InstanceTerminationActionDelete
+	machineScopeWithSpot.GCPMachine.Spec.ProvisioningModel = &spot
+	machineScopeWithSpot.GCPMachine.Spec.InstanceTerminationAction = &terminationActionDelete
+
+	machineScopeWithAccelerators, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	machineScopeWithAccelerators.GCPMachine.Spec.GuestAccelerators = []infrav1.Accelerator{
+		{Type: "nvidia-tesla-t4", Count: 2},
+	}
+
+	machineScopeWithConfidentialCompute, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	confidentialComputeEnabled := infrav1.ConfidentialComputePolicyEnabled
+	machineScopeWithConfidentialCompute.GCPMachine.Spec.ConfidentialCompute = &confidentialComputeEnabled
+	machineScopeWithConfidentialCompute.GCPMachine.Spec.ShieldedInstanceConfig = &infrav1.GCPShieldedInstanceConfig{
+		SecureBoot: infrav1.SecureBootPolicyEnabled,
+	}
+
+	machineScopeWithEncryptedDisks, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pdSsd := infrav1.PdSsdDiskType
+	machineScopeWithEncryptedDisks.GCPMachine.Spec.RootDiskEncryptionKey = &infrav1.CustomerEncryptionKey{
+		KeyType: infrav1.CustomerManagedKey,
+		ManagedKey: &infrav1.ManagedKey{
+			KMSKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key",
+		},
+	}
+	machineScopeWithEncryptedDisks.GCPMachine.Spec.AdditionalDisks = []infrav1.AttachedDiskSpec{
+		{
+			DeviceType: &pdSsd,
+			EncryptionKey: &infrav1.CustomerEncryptionKey{
+				KeyType: infrav1.CustomerSuppliedKey,
+				SuppliedKey: &infrav1.SuppliedKey{
+					RawKey: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-disk-key"},
+						Key:                  "key",
+					},
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name         string
 		scope        func() Scope
@@ -378,6 +500,324 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-a",
 			},
 		},
+		{
+			name:  "network resides in a shared vpc host project (should create instance in the host project network)",
+			scope: func() Scope { return machineScopeWithSharedVpc },
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network:    "projects/my-host-proj/global/networks/default",
+						Subnetwork: "projects/my-host-proj/regions/us-central1/subnetworks/my-subnet",
+					},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name:  "spot instance does not exist (should create spot instance)",
+			scope: func() Scope { return machineScopeWithSpot },
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{
+					AutomaticRestart:          pointer.Bool(false),
+					InstanceTerminationAction: "DELETE",
+					OnHostMaintenance:         "TERMINATE",
+					ProvisioningModel:         "SPOT",
+				},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name:  "instance with accelerators does not exist (should create instance with accelerators)",
+			scope: func() Scope { return machineScopeWithAccelerators },
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				GuestAccelerators: []*compute.AcceleratorConfig{
+					{
+						AcceleratorCount: 2,
+						AcceleratorType:  "zones/us-central1-c/acceleratorTypes/nvidia-tesla-t4",
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{
+					OnHostMaintenance: "TERMINATE",
+				},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name:  "instance with encrypted disks does not exist (should create instance with encrypted disks)",
+			scope: func() Scope { return machineScopeWithEncryptedDisks },
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+						DiskEncryptionKey: &compute.CustomerEncryptionKey{
+							KmsKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key",
+						},
+					},
+					{
+						AutoDelete: true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskSizeGb: 30,
+							DiskType:   "zones/us-central1-c/diskTypes/pd-ssd",
+						},
+						DiskEncryptionKey: &compute.CustomerEncryptionKey{
+							RawKey: "SGVsbG8gZnJvbSBHb29nbGUgQ2xvdWQgUGxhdGZvcm0=",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name:  "confidential instance does not exist (should create shielded and confidential instance)",
+			scope: func() Scope { return machineScopeWithConfidentialCompute },
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				ConfidentialInstanceConfig: &compute.ConfidentialInstanceConfig{
+					EnableConfidentialCompute: true,
+				},
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{
+					OnHostMaintenance: "TERMINATE",
+				},
+				ShieldedInstanceConfig: &compute.ShieldedInstanceConfig{
+					EnableSecureBoot:          true,
+					EnableVtpm:                true,
+					EnableIntegrityMonitoring: true,
+					ForceSendFields:           []string{"EnableSecureBoot", "EnableVtpm", "EnableIntegrityMonitoring"},
+				},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -396,3 +836,321 @@ func TestService_createOrGetInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestService_createOrGetInstanceConditions(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		insertErr  error
+		wantReason string
+	}{
+		{
+			name:       "quota exceeded while creating the instance",
+			insertErr:  &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}},
+			wantReason: infrav1.QuotaExceededReason,
+		},
+		{
+			name:       "zone exhausted while creating the instance",
+			insertErr:  &googleapi.Error{Code: http.StatusServiceUnavailable, Message: "ZONE_RESOURCE_POOL_EXHAUSTED - The zone does not have enough resources"},
+			wantReason: infrav1.ZoneResourcesExhaustedReason,
+		},
+		{
+			name:       "permission denied while creating the instance",
+			insertErr:  &googleapi.Error{Code: http.StatusForbidden},
+			wantReason: infrav1.PermissionDeniedReason,
+		},
+		{
+			name:       "unknown error while creating the instance",
+			insertErr:  &googleapi.Error{Code: http.StatusInternalServerError},
+			wantReason: infrav1.InstanceProvisionFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    fakeGCPMachine.DeepCopy(),
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+				InsertHook: func(ctx context.Context, key *meta.Key, obj *compute.Instance, m *cloud.MockInstances) (bool, error) {
+					return true, tt.insertErr
+				},
+			}
+			if _, err := s.createOrGetInstance(ctx); err == nil {
+				t.Fatal("Service.createOrGetInstance() expected an error")
+			}
+
+			if !conditions.IsTrue(machineScope.GCPMachine, infrav1.BootstrapDataReadyCondition) {
+				t.Errorf("Service.createOrGetInstance() expected %s condition to be true", infrav1.BootstrapDataReadyCondition)
+			}
+
+			if got := conditions.GetReason(machineScope.GCPMachine, infrav1.InstanceReadyCondition); got != tt.wantReason {
+				t.Errorf("Service.createOrGetInstance() %s reason = %s, want %s", infrav1.InstanceReadyCondition, got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestService_setInstanceReadyCondition(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spot := infrav1.ProvisioningModelSpot
+	tests := []struct {
+		name              string
+		provisioningModel *infrav1.ProvisioningModel
+		preemptible       bool
+		status            infrav1.InstanceStatus
+		wantReason        string
+	}{
+		{
+			name:              "stopped spot instance (should be reported as preempted)",
+			provisioningModel: &spot,
+			status:            infrav1.InstanceStatusStopped,
+			wantReason:        infrav1.InstancePreemptedReason,
+		},
+		{
+			name:        "terminated preemptible instance (should be reported as preempted)",
+			preemptible: true,
+			status:      infrav1.InstanceStatusTerminated,
+			wantReason:  infrav1.InstancePreemptedReason,
+		},
+		{
+			name:       "stopped standard instance (should be reported as stopped)",
+			status:     infrav1.InstanceStatusStopped,
+			wantReason: infrav1.InstanceStoppedReason,
+		},
+		{
+			name:       "terminated standard instance (should be reported as terminated)",
+			status:     infrav1.InstanceStatusTerminated,
+			wantReason: infrav1.InstanceTerminatedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcpMachine := fakeGCPMachine.DeepCopy()
+			gcpMachine.Spec.ProvisioningModel = tt.provisioningModel
+			gcpMachine.Spec.Preemptible = tt.preemptible
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(machineScope)
+			s.setInstanceReadyCondition(tt.status)
+			condition := conditions.Get(gcpMachine, infrav1.InstanceReadyCondition)
+			if condition == nil || condition.Reason != tt.wantReason {
+				t.Fatalf("Service.setInstanceReadyCondition() %s = %v, want reason %s", infrav1.InstanceReadyCondition, condition, tt.wantReason)
+			}
+
+			// The condition of an instance in the same state does not transition again.
+			transition := condition.LastTransitionTime
+			s.setInstanceReadyCondition(tt.status)
+			if got := conditions.Get(gcpMachine, infrav1.InstanceReadyCondition).LastTransitionTime; !got.Equal(&transition) {
+				t.Errorf("Service.setInstanceReadyCondition() moved the last transition time from %v to %v", transition, got)
+			}
+		})
+	}
+}
+
+func TestService_createOrGetInstanceReclaimed(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spot := infrav1.ProvisioningModelSpot
+	terminationActionDelete := infrav1.InstanceTerminationActionDelete
+	tests := []struct {
+		name              string
+		provisioningModel *infrav1.ProvisioningModel
+		providerID        *string
+		wantInsert        bool
+	}{
+		{
+			name:              "spot instance created before and missing now (should not recreate it)",
+			provisioningModel: &spot,
+			providerID:        pointer.String("gce://proj-id/us-central1-c/my-machine"),
+			wantInsert:        false,
+		},
+		{
+			name:              "spot instance never created (should create it)",
+			provisioningModel: &spot,
+			wantInsert:        true,
+		},
+		{
+			name:       "standard instance created before and missing now (should recreate it)",
+			providerID: pointer.String("gce://proj-id/us-central1-c/my-machine"),
+			wantInsert: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpMachine := fakeGCPMachine.DeepCopy()
+			gcpMachine.Spec.ProvisioningModel = tt.provisioningModel
+			if tt.provisioningModel != nil {
+				gcpMachine.Spec.InstanceTerminationAction = &terminationActionDelete
+			}
+			gcpMachine.Spec.ProviderID = tt.providerID
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			inserted := false
+			s := New(machineScope)
+			s.instances = &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+				InsertHook: func(ctx context.Context, key *meta.Key, obj *compute.Instance, m *cloud.MockInstances) (bool, error) {
+					inserted = true
+					return false, nil
+				},
+			}
+			instance, err := s.createOrGetInstance(ctx)
+			if err != nil {
+				t.Fatalf("Service.createOrGetInstance() error = %v", err)
+			}
+
+			if inserted != tt.wantInsert || (instance != nil) != tt.wantInsert {
+				t.Fatalf("Service.createOrGetInstance() inserted = %v, instance = %v, want %v", inserted, instance, tt.wantInsert)
+			}
+
+			if tt.wantInsert {
+				return
+			}
+
+			if got := conditions.GetReason(machineScope.GCPMachine, infrav1.InstanceReadyCondition); got != infrav1.InstancePreemptedReason {
+				t.Errorf("Service.createOrGetInstance() %s reason = %s, want %s", infrav1.InstanceReadyCondition, got, infrav1.InstancePreemptedReason)
+			}
+			if got := machineScope.GetInstanceStatus(); got == nil || *got != infrav1.InstanceStatusTerminated {
+				t.Errorf("Service.createOrGetInstance() instance status = %v, want %s", got, infrav1.InstanceStatusTerminated)
+			}
+		})
+	}
+}
+
+func TestService_Reconcile_userProvidedControlPlaneEndpoint(t *testing.T) {
+	ctx := context.TODO()
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	none := infrav1.None
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.LoadBalancerType = &none
+	gcpCluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "10.0.0.100", Port: 6443}
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  gcpCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machine := fakeMachine.DeepCopy()
+	machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       machine,
+		GCPMachine:    fakeGCPMachine.DeepCopy(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registered := false
+	s := New(machineScope)
+	s.instances = &cloud.MockInstances{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects: map[meta.Key]*cloud.MockInstancesObj{
+			*meta.ZonalKey("my-machine", "us-central1-c"): {Obj: &compute.Instance{
+				Name:   "my-machine",
+				Status: string(infrav1.InstanceStatusRunning),
+			}},
+		},
+	}
+	s.instancegroups = &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockInstanceGroupsObj{},
+		ListInstancesHook: func(_ context.Context, _ *meta.Key, _ *compute.InstanceGroupsListInstancesRequest, _ *filter.F, _ *cloud.MockInstanceGroups) ([]*compute.InstanceWithNamedPorts, error) {
+			registered = true
+			return nil, nil
+		},
+		AddInstancesHook: func(_ context.Context, _ *meta.Key, _ *compute.InstanceGroupsAddInstancesRequest, _ *cloud.MockInstanceGroups) error {
+			registered = true
+			return nil
+		},
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	if registered {
+		t.Errorf("Service.Reconcile() registered the control plane instance into an instance group")
+	}
+	if conditions.Has(machineScope.GCPMachine, infrav1.ControlPlaneLBRegisteredCondition) {
+		t.Errorf("Service.Reconcile() set the %s condition", infrav1.ControlPlaneLBRegisteredCondition)
+	}
+}
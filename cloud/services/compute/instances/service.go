@@ -40,9 +40,11 @@ type instancegroupsInterface interface {
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Machine
+	IsPreemptible() bool
 	InstanceSpec() *compute.Instance
 	InstanceImageSpec() *compute.AttachedDisk
 	InstanceAdditionalDiskSpec() []*compute.AttachedDisk
+	InstanceDiskEncryptionKeysSpec() ([]*compute.CustomerEncryptionKey, error)
 }
 
 // Service implements instances reconciler.
@@ -18,14 +18,27 @@ package loadbalancers
 
 import (
 	"context"
+	"net"
+	"path"
+	"regexp"
+	"strings"
 
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// maxConnectionsPerInstance is the target capacity of the instances balanced with the CONNECTION mode by a global
+// backend service. It only weighs the distribution of the connections between the zones, it doesn't cap them.
+const maxConnectionsPerInstance = 1000
+
 // Reconcile reconcile cluster control-plane loadbalancer compoenents.
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
@@ -35,6 +48,26 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	// A disabled internal API server endpoint is deleted before the global backend services switch back to the
+	// UTILIZATION balancing mode, see globalBackend.
+	if !s.scope.IAPEndpointEnabled() && s.scope.IAPEndpoint() != nil {
+		if err := s.deleteIAPEndpoint(ctx); err != nil {
+			return err
+		}
+	}
+
+	if s.scope.LoadBalancerType().IsRegional() {
+		if err := s.reconcileRegional(ctx, instancegroups); err != nil {
+			return err
+		}
+
+		if err := s.reconcileIAPEndpointEnabled(ctx, instancegroups); err != nil {
+			return err
+		}
+
+		return s.deleteStaleInstanceGroups(ctx)
+	}
+
 	healthcheck, err := s.createOrGetHealthCheck(ctx)
 	if err != nil {
 		return err
@@ -45,6 +78,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.reconcileSecurityPolicy(ctx, backendsvc); err != nil {
+		return err
+	}
+
 	target, err := s.createOrGetTargetTCPProxy(ctx, backendsvc)
 	if err != nil {
 		return err
@@ -55,13 +92,71 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	return s.createForwardingRule(ctx, target, addr)
+	if err := s.createForwardingRule(ctx, target, addr); err != nil {
+		return err
+	}
+
+	if err := s.reconcileAdditionalPorts(ctx, instancegroups, addr); err != nil {
+		return err
+	}
+
+	if err := s.reconcileIAPEndpointEnabled(ctx, instancegroups); err != nil {
+		return err
+	}
+
+	// The instance groups of the removed zones are drained from the backend services at this point.
+	return s.deleteStaleInstanceGroups(ctx)
+}
+
+// reconcileIAPEndpointEnabled creates the internal API server endpoint when it is enabled.
+func (s *Service) reconcileIAPEndpointEnabled(ctx context.Context, instancegroups []*compute.InstanceGroup) error {
+	if !s.scope.IAPEndpointEnabled() {
+		return nil
+	}
+
+	return s.reconcileIAPEndpoint(ctx, instancegroups)
+}
+
+// globalBackend returns the backend of a global backend service balancing the instance group. GCE only accepts an
+// instance group in several backend services when they balance it with the same mode, or with a mix of the
+// CONNECTION and RATE modes: the instance groups shared with the regional backend service of the internal API server
+// endpoint, which only supports the CONNECTION mode, are balanced with the CONNECTION mode by the global backend
+// services too.
+func (s *Service) globalBackend(group *compute.InstanceGroup) *compute.Backend {
+	if s.scope.IAPEndpointEnabled() {
+		return &compute.Backend{
+			BalancingMode:             "CONNECTION",
+			MaxConnectionsPerInstance: maxConnectionsPerInstance,
+			Group:                     group.SelfLink,
+		}
+	}
+
+	return &compute.Backend{
+		BalancingMode: "UTILIZATION",
+		Group:         group.SelfLink,
+	}
 }
 
 // Delete delete cluster control-plane loadbalancer compoenents.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
 	log.Info("Deleting loadbalancer resources")
+	if s.scope.LoadBalancerType().IsRegional() {
+		if err := s.deleteRegional(ctx); err != nil {
+			return err
+		}
+
+		if err := s.deleteIAPEndpoint(ctx); err != nil {
+			return err
+		}
+
+		return s.deleteInstanceGroups(ctx)
+	}
+
+	if err := s.deleteAdditionalPorts(ctx); err != nil {
+		return err
+	}
+
 	if err := s.deleteForwardingRule(ctx); err != nil {
 		return err
 	}
@@ -74,6 +169,10 @@ func (s *Service) Delete(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.deleteSecurityPolicy(ctx); err != nil {
+		return err
+	}
+
 	if err := s.deleteBackendService(ctx); err != nil {
 		return err
 	}
@@ -82,6 +181,10 @@ func (s *Service) Delete(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.deleteIAPEndpoint(ctx); err != nil {
+		return err
+	}
+
 	return s.deleteInstanceGroups(ctx)
 }
 
@@ -121,6 +224,17 @@ func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.Ins
 			}
 		}
 
+		if !namedPortsMatch(instancegroup.NamedPorts, instancegroupSpec.NamedPorts) {
+			log.V(2).Info("Updating instancegroup named ports in zone", "zone", zone, "name", instancegroupSpec.Name)
+			if err := s.instancegroups.SetNamedPorts(ctx, meta.ZonalKey(instancegroupSpec.Name, zone), &compute.InstanceGroupsSetNamedPortsRequest{
+				NamedPorts:  instancegroupSpec.NamedPorts,
+				Fingerprint: instancegroup.Fingerprint,
+			}); err != nil {
+				log.Error(err, "Error updating instancegroup named ports", "name", instancegroupSpec.Name)
+				return groups, err
+			}
+		}
+
 		groups = append(groups, instancegroup)
 		groupsMap[zone] = instancegroup.SelfLink
 	}
@@ -152,6 +266,19 @@ func (s *Service) createOrGetHealthCheck(ctx context.Context) (*compute.HealthCh
 		}
 	}
 
+	if !healthCheckMatches(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating a healthcheck", "name", healthcheckSpec.Name)
+		if err := s.healthchecks.Update(ctx, meta.GlobalKey(healthcheckSpec.Name), healthcheckSpec); err != nil {
+			log.Error(err, "Error updating a healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		healthcheck, err = s.healthchecks.Get(ctx, meta.GlobalKey(healthcheckSpec.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	s.scope.Network().APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
 	return healthcheck, nil
 }
@@ -160,10 +287,7 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 	log := log.FromContext(ctx)
 	backends := make([]*compute.Backend, 0, len(instancegroups))
 	for _, group := range instancegroups {
-		backends = append(backends, &compute.Backend{
-			BalancingMode: "UTILIZATION",
-			Group:         group.SelfLink,
-		})
+		backends = append(backends, s.globalBackend(group))
 	}
 
 	backendsvcSpec := s.scope.BackendServiceSpec()
@@ -188,9 +312,9 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 		}
 	}
 
-	if len(backendsvc.Backends) != len(backendsvcSpec.Backends) {
+	if !backendServiceMatches(backendsvc, backendsvcSpec) {
 		log.V(2).Info("Updating a backendservice", "name", backendsvcSpec.Name)
-		backendsvc.Backends = backendsvcSpec.Backends
+		applyBackendService(backendsvc, backendsvcSpec)
 		if err := s.backendservices.Update(ctx, meta.GlobalKey(backendsvcSpec.Name), backendsvc); err != nil {
 			log.Error(err, "Error updating a backendservice", "name", backendsvcSpec.Name)
 			return nil, err
@@ -230,6 +354,10 @@ func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *comput
 
 func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, error) {
 	log := log.FromContext(ctx)
+	if s.scope.LoadBalancerReservedAddress() != "" {
+		return s.getReservedAddress(ctx)
+	}
+
 	addrSpec := s.scope.AddressSpec()
 	log.V(2).Info("Looking for address", "name", addrSpec.Name)
 	addr, err := s.addresses.Get(ctx, meta.GlobalKey(addrSpec.Name))
@@ -251,11 +379,60 @@ func (s *Service) createOrGetAddress(ctx context.Context) (*compute.Address, err
 		}
 	}
 
+	s.setAPIServerAddress(addr)
+	return addr, nil
+}
+
+// getReservedAddress returns the existing address of the load balancer, looked up by IP address or by name.
+// The address is not owned by the cluster.
+func (s *Service) getReservedAddress(ctx context.Context) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	reserved := s.scope.LoadBalancerReservedAddress()
+	regional := s.scope.LoadBalancerType().IsRegional()
+	log.V(2).Info("Looking for reserved address", "address", reserved)
+
+	var addr *compute.Address
+	if net.ParseIP(reserved) != nil {
+		fl := filter.Regexp("address", regexp.QuoteMeta(reserved))
+		var addrs []*compute.Address
+		var err error
+		if regional {
+			addrs, err = s.regionaddresses.List(ctx, s.scope.Region(), fl)
+		} else {
+			addrs, err = s.addresses.List(ctx, fl)
+		}
+		if err != nil {
+			log.Error(err, "Error looking for reserved address", "address", reserved)
+			return nil, err
+		}
+
+		if len(addrs) == 0 {
+			return nil, errors.Errorf("reserved address %s not found", reserved)
+		}
+		addr = addrs[0]
+	} else {
+		var err error
+		if regional {
+			addr, err = s.regionaddresses.Get(ctx, meta.RegionalKey(reserved, s.scope.Region()))
+		} else {
+			addr, err = s.addresses.Get(ctx, meta.GlobalKey(reserved))
+		}
+		if err != nil {
+			log.Error(err, "Error looking for reserved address", "address", reserved)
+			return nil, errors.Wrapf(err, "failed to get reserved address %s", reserved)
+		}
+	}
+
+	s.setAPIServerAddress(addr)
+	return addr, nil
+}
+
+// setAPIServerAddress records the address of the load balancer as the control plane endpoint.
+func (s *Service) setAPIServerAddress(addr *compute.Address) {
 	s.scope.Network().APIServerAddress = pointer.String(addr.SelfLink)
 	endpoint := s.scope.ControlPlaneEndpoint()
 	endpoint.Host = addr.Address
 	s.scope.SetControlPlaneEndpoint(endpoint)
-	return addr, nil
 }
 
 func (s *Service) createForwardingRule(ctx context.Context, target *compute.TargetTcpProxy, addr *compute.Address) error {
@@ -304,6 +481,12 @@ func (s *Service) deleteForwardingRule(ctx context.Context) error {
 
 func (s *Service) deleteAddress(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.LoadBalancerReservedAddress() != "" {
+		// The reserved address is not owned by the cluster.
+		s.scope.Network().APIServerAddress = nil
+		return nil
+	}
+
 	spec := s.scope.AddressSpec()
 	key := meta.GlobalKey(spec.Name)
 	log.V(2).Info("Deleting a address", "name", spec.Name)
@@ -363,15 +546,968 @@ func (s *Service) deleteInstanceGroups(ctx context.Context) error {
 		spec := s.scope.InstanceGroupSpec(zone)
 		key := meta.ZonalKey(spec.Name, zone)
 		log.V(2).Info("Deleting a instancegroup", "name", spec.Name)
-		if err := s.instancegroups.Delete(ctx, key); err != nil {
+		if err := s.instancegroups.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
+			return err
+		}
+
+		delete(s.scope.Network().APIServerInstanceGroups, zone)
+	}
+
+	return nil
+}
+
+// deleteStaleInstanceGroups deletes the instance groups of the zones removed from the failure domains.
+// They must be removed from the backend services first.
+func (s *Service) deleteStaleInstanceGroups(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	fd := s.scope.FailureDomains()
+	for zone := range s.scope.Network().APIServerInstanceGroups {
+		if _, ok := fd[zone]; ok {
+			continue
+		}
+
+		spec := s.scope.InstanceGroupSpec(zone)
+		log.V(2).Info("Deleting a stale instancegroup", "name", spec.Name, "zone", zone)
+		if err := s.instancegroups.Delete(ctx, meta.ZonalKey(spec.Name, zone)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting a stale instancegroup", "name", spec.Name)
+			return err
+		}
+
+		delete(s.scope.Network().APIServerInstanceGroups, zone)
+	}
+
+	return nil
+}
+
+// reconcileAdditionalPorts creates the load balancer components of the additional ports, sharing the address and
+// the instance groups of the API server, and deletes the ones of the ports removed from the spec.
+func (s *Service) reconcileAdditionalPorts(ctx context.Context, instancegroups []*compute.InstanceGroup, addr *compute.Address) error {
+	desired := sets.NewString()
+	for _, port := range s.scope.AdditionalPorts() {
+		desired.Insert(port.Name)
+		if err := s.reconcileAdditionalPort(ctx, port, instancegroups, addr); err != nil {
+			return err
+		}
+	}
+
+	for name := range s.scope.Network().AdditionalPortForwardingRules {
+		if desired.Has(name) {
+			continue
+		}
+
+		if err := s.deleteAdditionalPort(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileAdditionalPort creates the health check, backend service, target proxy when the load balancer is global,
+// and forwarding rule of an additional port.
+func (s *Service) reconcileAdditionalPort(ctx context.Context, port infrav1.LoadBalancerPort, instancegroups []*compute.InstanceGroup, addr *compute.Address) error {
+	log := log.FromContext(ctx)
+	regional := s.scope.LoadBalancerType().IsRegional()
+	healthchecks, backendservices, forwardingrules := s.healthchecks, regionbackendservicesInterface(s.backendservices), s.forwardingrules
+	keyOf := meta.GlobalKey
+	if regional {
+		healthchecks, backendservices, forwardingrules = s.regionhealthchecks, s.regionbackendservices, s.regionforwardingrules
+		keyOf = func(name string) *meta.Key { return meta.RegionalKey(name, s.scope.Region()) }
+	}
+
+	healthcheckSpec := s.scope.AdditionalPortHealthCheckSpec(port)
+	key := keyOf(healthcheckSpec.Name)
+	log.V(2).Info("Looking for additional port healthcheck", "name", healthcheckSpec.Name)
+	healthcheck, err := healthchecks.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for additional port healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an additional port healthcheck", "name", healthcheckSpec.Name)
+		if err := healthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error creating an additional port healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		healthcheck, err = healthchecks.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !healthCheckMatches(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating an additional port healthcheck", "name", healthcheckSpec.Name)
+		if err := healthchecks.Update(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error updating an additional port healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		healthcheck, err = healthchecks.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	backends := make([]*compute.Backend, 0, len(instancegroups))
+	for _, group := range instancegroups {
+		backend := s.globalBackend(group)
+		if regional {
+			backend = &compute.Backend{
+				BalancingMode: "CONNECTION",
+				Group:         group.SelfLink,
+			}
+		}
+		backends = append(backends, backend)
+	}
+
+	backendsvcSpec := s.scope.AdditionalPortBackendServiceSpec(port)
+	backendsvcSpec.Backends = backends
+	backendsvcSpec.HealthChecks = []string{healthcheck.SelfLink}
+	key = keyOf(backendsvcSpec.Name)
+	log.V(2).Info("Looking for additional port backendservice", "name", backendsvcSpec.Name)
+	backendsvc, err := backendservices.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for additional port backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an additional port backendservice", "name", backendsvcSpec.Name)
+		if err := backendservices.Insert(ctx, key, backendsvcSpec); err != nil {
+			log.Error(err, "Error creating an additional port backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+
+		backendsvc, err = backendservices.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !backendServiceMatches(backendsvc, backendsvcSpec) {
+		log.V(2).Info("Updating an additional port backendservice", "name", backendsvcSpec.Name)
+		applyBackendService(backendsvc, backendsvcSpec)
+		if err := backendservices.Update(ctx, key, backendsvc); err != nil {
+			log.Error(err, "Error updating an additional port backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+	}
+
+	spec := s.scope.AdditionalPortForwardingRuleSpec(port)
+	spec.IPAddress = addr.SelfLink
+	if regional {
+		spec.BackendService = backendsvc.SelfLink
+	} else {
+		targetSpec := s.scope.AdditionalPortTargetTCPProxySpec(port)
+		targetSpec.Service = backendsvc.SelfLink
+		key = meta.GlobalKey(targetSpec.Name)
+		log.V(2).Info("Looking for additional port targettcpproxy", "name", targetSpec.Name)
+		target, err := s.targettcpproxies.Get(ctx, key)
+		if err != nil {
 			if !gcperrors.IsNotFound(err) {
-				log.Error(err, "Error deleting a instancegroup", "name", spec.Name)
+				log.Error(err, "Error looking for additional port targettcpproxy", "name", targetSpec.Name)
+				return err
+			}
+
+			log.V(2).Info("Creating an additional port targettcpproxy", "name", targetSpec.Name)
+			if err := s.targettcpproxies.Insert(ctx, key, targetSpec); err != nil {
+				log.Error(err, "Error creating an additional port targettcpproxy", "name", targetSpec.Name)
+				return err
+			}
+
+			target, err = s.targettcpproxies.Get(ctx, key)
+			if err != nil {
 				return err
 			}
+		}
+		spec.Target = target.SelfLink
+	}
+
+	key = keyOf(spec.Name)
+	log.V(2).Info("Looking for additional port forwardingrule", "name", spec.Name)
+	forwarding, err := forwardingrules.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for additional port forwardingrule", "name", spec.Name)
+			return err
+		}
 
-			delete(s.scope.Network().APIServerInstanceGroups, zone)
+		log.V(2).Info("Creating an additional port forwardingrule", "name", spec.Name)
+		if err := forwardingrules.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating an additional port forwardingrule", "name", spec.Name)
+			return err
+		}
+
+		forwarding, err = forwardingrules.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.scope.Network().AdditionalPortForwardingRules == nil {
+		s.scope.Network().AdditionalPortForwardingRules = make(map[string]string)
+	}
+	s.scope.Network().AdditionalPortForwardingRules[port.Name] = forwarding.SelfLink
+	return nil
+}
+
+// deleteAdditionalPorts deletes the load balancer components of all the additional ports, the ones in the spec
+// and the ones still recorded in the status.
+func (s *Service) deleteAdditionalPorts(ctx context.Context) error {
+	names := sets.StringKeySet(s.scope.Network().AdditionalPortForwardingRules)
+	for _, port := range s.scope.AdditionalPorts() {
+		names.Insert(port.Name)
+	}
+
+	for _, name := range names.List() {
+		if err := s.deleteAdditionalPort(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteAdditionalPort deletes the forwarding rule, target proxy, backend service and health check of an
+// additional port.
+func (s *Service) deleteAdditionalPort(ctx context.Context, name string) error {
+	log := log.FromContext(ctx)
+	port := infrav1.LoadBalancerPort{Name: name}
+	regional := s.scope.LoadBalancerType().IsRegional()
+	healthchecks, backendservices, forwardingrules := s.healthchecks, regionbackendservicesInterface(s.backendservices), s.forwardingrules
+	keyOf := meta.GlobalKey
+	if regional {
+		healthchecks, backendservices, forwardingrules = s.regionhealthchecks, s.regionbackendservices, s.regionforwardingrules
+		keyOf = func(name string) *meta.Key { return meta.RegionalKey(name, s.scope.Region()) }
+	}
+
+	forwardingRuleName := s.scope.AdditionalPortForwardingRuleSpec(port).Name
+	log.V(2).Info("Deleting an additional port forwardingrule", "name", forwardingRuleName)
+	if err := forwardingrules.Delete(ctx, keyOf(forwardingRuleName)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an additional port forwardingrule", "name", forwardingRuleName)
+		return err
+	}
+
+	if !regional {
+		targetName := s.scope.AdditionalPortTargetTCPProxySpec(port).Name
+		log.V(2).Info("Deleting an additional port targettcpproxy", "name", targetName)
+		if err := s.targettcpproxies.Delete(ctx, meta.GlobalKey(targetName)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting an additional port targettcpproxy", "name", targetName)
+			return err
+		}
+	}
+
+	backendServiceName := s.scope.AdditionalPortBackendServiceSpec(port).Name
+	log.V(2).Info("Deleting an additional port backendservice", "name", backendServiceName)
+	if err := backendservices.Delete(ctx, keyOf(backendServiceName)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an additional port backendservice", "name", backendServiceName)
+		return err
+	}
+
+	healthCheckName := s.scope.AdditionalPortHealthCheckSpec(port).Name
+	log.V(2).Info("Deleting an additional port healthcheck", "name", healthCheckName)
+	if err := healthchecks.Delete(ctx, keyOf(healthCheckName)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an additional port healthcheck", "name", healthCheckName)
+		return err
+	}
+
+	delete(s.scope.Network().AdditionalPortForwardingRules, name)
+	return nil
+}
+
+// reconcileSecurityPolicy attaches the security policy to the backend service, creating the policy when it is
+// defined inline, or detaches it when it has been removed from the spec.
+func (s *Service) reconcileSecurityPolicy(ctx context.Context, backendsvc *compute.BackendService) error {
+	log := log.FromContext(ctx)
+	name := s.scope.SecurityPolicyName()
+	if name == "" {
+		return s.deleteSecurityPolicy(ctx)
+	}
+
+	policy, err := s.createOrGetSecurityPolicy(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if resourcePath(backendsvc.SecurityPolicy) != resourcePath(policy.SelfLink) {
+		log.V(2).Info("Attaching a securitypolicy to the backendservice", "name", name, "backendservice", backendsvc.Name)
+		ref := &compute.SecurityPolicyReference{SecurityPolicy: policy.SelfLink}
+		if err := s.backendservices.SetSecurityPolicy(ctx, meta.GlobalKey(backendsvc.Name), ref); err != nil {
+			log.Error(err, "Error attaching a securitypolicy to the backendservice", "name", name, "backendservice", backendsvc.Name)
+			return err
 		}
 	}
 
+	// The policy attached previously is replaced, e.g. the inline allow-list by a referenced policy.
+	if previous := s.scope.Network().APIServerSecurityPolicy; previous != nil && resourcePath(*previous) != resourcePath(policy.SelfLink) {
+		if err := s.deleteOwnedSecurityPolicy(ctx, path.Base(*previous)); err != nil {
+			return err
+		}
+	}
+
+	s.scope.Network().APIServerSecurityPolicy = pointer.String(policy.SelfLink)
 	return nil
 }
+
+func (s *Service) createOrGetSecurityPolicy(ctx context.Context, name string) (*computebeta.SecurityPolicy, error) {
+	log := log.FromContext(ctx)
+	key := meta.GlobalKey(name)
+	spec := s.scope.SecurityPolicySpec()
+	if spec == nil {
+		// The referenced security policy is not managed by the cluster.
+		policy, err := s.securitypolicies.Get(ctx, key)
+		if err != nil {
+			log.Error(err, "Error looking for securitypolicy", "name", name)
+			return nil, err
+		}
+
+		return policy, nil
+	}
+
+	policy, err := s.securitypolicies.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for securitypolicy", "name", name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a securitypolicy", "name", name)
+		if err := s.securitypolicies.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating a securitypolicy", "name", name)
+			return nil, err
+		}
+
+		return s.securitypolicies.Get(ctx, key)
+	}
+
+	// Only the allow rule, at priority 0, follows the spec. The default rule denying the other sources, at priority
+	// MaxInt32, is never changed.
+	allow := spec.Rules[0]
+	var current *computebeta.SecurityPolicyRule
+	for _, rule := range policy.Rules {
+		if rule.Priority == allow.Priority {
+			current = rule
+			break
+		}
+	}
+
+	switch {
+	case current == nil:
+		log.V(2).Info("Adding the allow rule to the securitypolicy", "name", name)
+		if err := s.securitypolicies.AddRule(ctx, key, allow); err != nil {
+			log.Error(err, "Error adding the allow rule to the securitypolicy", "name", name)
+			return nil, err
+		}
+	case !securityPolicyRuleMatches(current, allow):
+		log.V(2).Info("Updating the allow rule of the securitypolicy", "name", name)
+		if err := s.securitypolicies.PatchRule(ctx, key, allow); err != nil {
+			log.Error(err, "Error updating the allow rule of the securitypolicy", "name", name)
+			return nil, err
+		}
+	}
+
+	return policy, nil
+}
+
+// deleteSecurityPolicy detaches the security policy from the backend service, and deletes it when it is owned
+// by the cluster.
+func (s *Service) deleteSecurityPolicy(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	link := s.scope.Network().APIServerSecurityPolicy
+	if link == nil {
+		return nil
+	}
+
+	spec := s.scope.BackendServiceSpec()
+	log.V(2).Info("Detaching a securitypolicy from the backendservice", "name", *link, "backendservice", spec.Name)
+	if err := s.backendservices.SetSecurityPolicy(ctx, meta.GlobalKey(spec.Name), &compute.SecurityPolicyReference{}); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error detaching a securitypolicy from the backendservice", "name", *link, "backendservice", spec.Name)
+		return err
+	}
+
+	if err := s.deleteOwnedSecurityPolicy(ctx, path.Base(*link)); err != nil {
+		return err
+	}
+
+	s.scope.Network().APIServerSecurityPolicy = nil
+	return nil
+}
+
+// deleteOwnedSecurityPolicy deletes the security policy if it was created for the cluster.
+func (s *Service) deleteOwnedSecurityPolicy(ctx context.Context, name string) error {
+	log := log.FromContext(ctx)
+	key := meta.GlobalKey(name)
+	policy, err := s.securitypolicies.Get(ctx, key)
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if policy.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting a securitypolicy", "name", name)
+	if err := s.securitypolicies.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a securitypolicy", "name", name)
+		return err
+	}
+
+	return nil
+}
+
+// reconcileRegional reconciles the regional components of an internal or external passthrough loadbalancer.
+func (s *Service) reconcileRegional(ctx context.Context, instancegroups []*compute.InstanceGroup) error {
+	healthcheck, err := s.createOrGetRegionalHealthCheck(ctx)
+	if err != nil {
+		return err
+	}
+
+	backendsvc, err := s.createOrGetRegionalBackendService(ctx, instancegroups, healthcheck)
+	if err != nil {
+		return err
+	}
+
+	addr, err := s.createOrGetRegionalAddress(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.createRegionalForwardingRule(ctx, backendsvc, addr); err != nil {
+		return err
+	}
+
+	return s.reconcileAdditionalPorts(ctx, instancegroups, addr)
+}
+
+// deleteRegional deletes the regional components of an internal or external passthrough loadbalancer.
+func (s *Service) deleteRegional(ctx context.Context) error {
+	if err := s.deleteAdditionalPorts(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteRegionalForwardingRule(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteRegionalAddress(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteRegionalBackendService(ctx); err != nil {
+		return err
+	}
+
+	return s.deleteRegionalHealthCheck(ctx)
+}
+
+func (s *Service) createOrGetRegionalHealthCheck(ctx context.Context) (*compute.HealthCheck, error) {
+	log := log.FromContext(ctx)
+	healthcheckSpec := s.scope.HealthCheckSpec()
+	key := meta.RegionalKey(healthcheckSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for regional healthcheck", "name", healthcheckSpec.Name)
+	healthcheck, err := s.regionhealthchecks.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for regional healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a regional healthcheck", "name", healthcheckSpec.Name)
+		if err := s.regionhealthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error creating a regional healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		healthcheck, err = s.regionhealthchecks.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !healthCheckMatches(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating a regional healthcheck", "name", healthcheckSpec.Name)
+		if err := s.regionhealthchecks.Update(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error updating a regional healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		healthcheck, err = s.regionhealthchecks.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
+	return healthcheck, nil
+}
+
+func (s *Service) createOrGetRegionalBackendService(ctx context.Context, instancegroups []*compute.InstanceGroup, healthcheck *compute.HealthCheck) (*compute.BackendService, error) {
+	log := log.FromContext(ctx)
+	backends := make([]*compute.Backend, 0, len(instancegroups))
+	for _, group := range instancegroups {
+		backends = append(backends, &compute.Backend{
+			BalancingMode: "CONNECTION",
+			Group:         group.SelfLink,
+		})
+	}
+
+	backendsvcSpec := s.scope.BackendServiceSpec()
+	backendsvcSpec.Backends = backends
+	backendsvcSpec.HealthChecks = []string{healthcheck.SelfLink}
+	key := meta.RegionalKey(backendsvcSpec.Name, s.scope.Region())
+	backendsvc, err := s.regionbackendservices.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for regional backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a regional backendservice", "name", backendsvcSpec.Name)
+		if err := s.regionbackendservices.Insert(ctx, key, backendsvcSpec); err != nil {
+			log.Error(err, "Error creating a regional backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+
+		backendsvc, err = s.regionbackendservices.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !backendServiceMatches(backendsvc, backendsvcSpec) {
+		log.V(2).Info("Updating a regional backendservice", "name", backendsvcSpec.Name)
+		applyBackendService(backendsvc, backendsvcSpec)
+		if err := s.regionbackendservices.Update(ctx, key, backendsvc); err != nil {
+			log.Error(err, "Error updating a regional backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIServerBackendService = pointer.String(backendsvc.SelfLink)
+	return backendsvc, nil
+}
+
+func (s *Service) createOrGetRegionalAddress(ctx context.Context) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	if s.scope.LoadBalancerReservedAddress() != "" {
+		return s.getReservedAddress(ctx)
+	}
+
+	addrSpec := s.scope.AddressSpec()
+	key := meta.RegionalKey(addrSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for regional address", "name", addrSpec.Name)
+	addr, err := s.regionaddresses.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for regional address", "name", addrSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a regional address", "name", addrSpec.Name)
+		if err := s.regionaddresses.Insert(ctx, key, addrSpec); err != nil {
+			log.Error(err, "Error creating a regional address", "name", addrSpec.Name)
+			return nil, err
+		}
+
+		addr, err = s.regionaddresses.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.setAPIServerAddress(addr)
+	return addr, nil
+}
+
+func (s *Service) createRegionalForwardingRule(ctx context.Context, backendsvc *compute.BackendService, addr *compute.Address) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.ForwardingRuleSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	spec.IPAddress = addr.SelfLink
+	spec.BackendService = backendsvc.SelfLink
+	log.V(2).Info("Looking for regional forwardingrule", "name", spec.Name)
+	forwarding, err := s.regionforwardingrules.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for regional forwardingrule", "name", spec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating a regional forwardingrule", "name", spec.Name)
+		if err := s.regionforwardingrules.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating a regional forwardingrule", "name", spec.Name)
+			return err
+		}
+
+		forwarding, err = s.regionforwardingrules.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.scope.Network().APIServerForwardingRule = pointer.String(forwarding.SelfLink)
+	return nil
+}
+
+func (s *Service) deleteRegionalForwardingRule(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.ForwardingRuleSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting a regional forwardingrule", "name", spec.Name)
+	if err := s.regionforwardingrules.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a regional forwardingrule", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIServerForwardingRule = nil
+	return nil
+}
+
+func (s *Service) deleteRegionalAddress(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.LoadBalancerReservedAddress() != "" {
+		// The reserved address is not owned by the cluster.
+		s.scope.Network().APIServerAddress = nil
+		return nil
+	}
+
+	spec := s.scope.AddressSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting a regional address", "name", spec.Name)
+	if err := s.regionaddresses.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		return err
+	}
+
+	s.scope.Network().APIServerAddress = nil
+	return nil
+}
+
+func (s *Service) deleteRegionalBackendService(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.BackendServiceSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting a regional backendservice", "name", spec.Name)
+	if err := s.regionbackendservices.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a regional backendservice", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIServerBackendService = nil
+	return nil
+}
+
+func (s *Service) deleteRegionalHealthCheck(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.HealthCheckSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting a regional healthcheck", "name", spec.Name)
+	if err := s.regionhealthchecks.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a regional healthcheck", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIServerHealthCheck = nil
+	return nil
+}
+
+// reconcileIAPEndpoint reconciles the internal passthrough loadbalancer giving a private endpoint to the API server
+// of a cluster with an external loadbalancer.
+func (s *Service) reconcileIAPEndpoint(ctx context.Context, instancegroups []*compute.InstanceGroup) error {
+	log := log.FromContext(ctx)
+	status := &infrav1.IAPEndpointStatus{}
+	region := s.scope.Region()
+
+	healthcheckSpec := s.scope.IAPEndpointHealthCheckSpec()
+	key := meta.RegionalKey(healthcheckSpec.Name, region)
+	log.V(2).Info("Looking for internal endpoint healthcheck", "name", healthcheckSpec.Name)
+	healthcheck, err := s.regionhealthchecks.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal endpoint healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an internal endpoint healthcheck", "name", healthcheckSpec.Name)
+		if err := s.regionhealthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error creating an internal endpoint healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		healthcheck, err = s.regionhealthchecks.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !healthCheckMatches(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating an internal endpoint healthcheck", "name", healthcheckSpec.Name)
+		if err := s.regionhealthchecks.Update(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error updating an internal endpoint healthcheck", "name", healthcheckSpec.Name)
+			return err
+		}
+
+		healthcheck, err = s.regionhealthchecks.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	status.HealthCheck = pointer.String(healthcheck.SelfLink)
+
+	backends := make([]*compute.Backend, 0, len(instancegroups))
+	for _, group := range instancegroups {
+		backends = append(backends, &compute.Backend{
+			BalancingMode: "CONNECTION",
+			Group:         group.SelfLink,
+		})
+	}
+
+	backendsvcSpec := s.scope.IAPEndpointBackendServiceSpec()
+	backendsvcSpec.Backends = backends
+	backendsvcSpec.HealthChecks = []string{healthcheck.SelfLink}
+	key = meta.RegionalKey(backendsvcSpec.Name, region)
+	log.V(2).Info("Looking for internal endpoint backendservice", "name", backendsvcSpec.Name)
+	backendsvc, err := s.regionbackendservices.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal endpoint backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an internal endpoint backendservice", "name", backendsvcSpec.Name)
+		if err := s.regionbackendservices.Insert(ctx, key, backendsvcSpec); err != nil {
+			log.Error(err, "Error creating an internal endpoint backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+
+		backendsvc, err = s.regionbackendservices.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !backendServiceMatches(backendsvc, backendsvcSpec) {
+		log.V(2).Info("Updating an internal endpoint backendservice", "name", backendsvcSpec.Name)
+		applyBackendService(backendsvc, backendsvcSpec)
+		if err := s.regionbackendservices.Update(ctx, key, backendsvc); err != nil {
+			log.Error(err, "Error updating an internal endpoint backendservice", "name", backendsvcSpec.Name)
+			return err
+		}
+	}
+	status.BackendService = pointer.String(backendsvc.SelfLink)
+
+	addrSpec := s.scope.IAPEndpointAddressSpec()
+	key = meta.RegionalKey(addrSpec.Name, region)
+	log.V(2).Info("Looking for internal endpoint address", "name", addrSpec.Name)
+	addr, err := s.regionaddresses.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal endpoint address", "name", addrSpec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an internal endpoint address", "name", addrSpec.Name)
+		if err := s.regionaddresses.Insert(ctx, key, addrSpec); err != nil {
+			log.Error(err, "Error creating an internal endpoint address", "name", addrSpec.Name)
+			return err
+		}
+
+		addr, err = s.regionaddresses.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	status.Address = pointer.String(addr.SelfLink)
+	status.Host = pointer.String(addr.Address)
+
+	spec := s.scope.IAPEndpointForwardingRuleSpec()
+	spec.IPAddress = addr.SelfLink
+	spec.BackendService = backendsvc.SelfLink
+	key = meta.RegionalKey(spec.Name, region)
+	log.V(2).Info("Looking for internal endpoint forwardingrule", "name", spec.Name)
+	forwarding, err := s.regionforwardingrules.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal endpoint forwardingrule", "name", spec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating an internal endpoint forwardingrule", "name", spec.Name)
+		if err := s.regionforwardingrules.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating an internal endpoint forwardingrule", "name", spec.Name)
+			return err
+		}
+
+		forwarding, err = s.regionforwardingrules.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+	status.ForwardingRule = pointer.String(forwarding.SelfLink)
+
+	s.scope.SetIAPEndpoint(status)
+	return nil
+}
+
+// deleteIAPEndpoint deletes the internal passthrough loadbalancer of the internal API server endpoint.
+func (s *Service) deleteIAPEndpoint(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	region := s.scope.Region()
+
+	forwardingRuleName := s.scope.IAPEndpointForwardingRuleSpec().Name
+	log.V(2).Info("Deleting an internal endpoint forwardingrule", "name", forwardingRuleName)
+	if err := s.regionforwardingrules.Delete(ctx, meta.RegionalKey(forwardingRuleName, region)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal endpoint forwardingrule", "name", forwardingRuleName)
+		return err
+	}
+
+	addressName := s.scope.IAPEndpointAddressSpec().Name
+	log.V(2).Info("Deleting an internal endpoint address", "name", addressName)
+	if err := s.regionaddresses.Delete(ctx, meta.RegionalKey(addressName, region)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal endpoint address", "name", addressName)
+		return err
+	}
+
+	backendServiceName := s.scope.IAPEndpointBackendServiceSpec().Name
+	log.V(2).Info("Deleting an internal endpoint backendservice", "name", backendServiceName)
+	if err := s.regionbackendservices.Delete(ctx, meta.RegionalKey(backendServiceName, region)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal endpoint backendservice", "name", backendServiceName)
+		return err
+	}
+
+	healthCheckName := s.scope.IAPEndpointHealthCheckSpec().Name
+	log.V(2).Info("Deleting an internal endpoint healthcheck", "name", healthCheckName)
+	if err := s.regionhealthchecks.Delete(ctx, meta.RegionalKey(healthCheckName, region)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal endpoint healthcheck", "name", healthCheckName)
+		return err
+	}
+
+	s.scope.SetIAPEndpoint(nil)
+	return nil
+}
+
+// healthCheckMatches returns true if the health check probes the API server the way the spec does.
+func healthCheckMatches(current, desired *compute.HealthCheck) bool {
+	if current.Type != desired.Type ||
+		current.CheckIntervalSec != desired.CheckIntervalSec ||
+		current.TimeoutSec != desired.TimeoutSec ||
+		current.HealthyThreshold != desired.HealthyThreshold ||
+		current.UnhealthyThreshold != desired.UnhealthyThreshold {
+		return false
+	}
+
+	if desired.SslHealthCheck != nil {
+		return current.SslHealthCheck != nil &&
+			current.SslHealthCheck.Port == desired.SslHealthCheck.Port &&
+			current.SslHealthCheck.PortSpecification == desired.SslHealthCheck.PortSpecification
+	}
+
+	if desired.TcpHealthCheck != nil {
+		return current.TcpHealthCheck != nil &&
+			current.TcpHealthCheck.Port == desired.TcpHealthCheck.Port &&
+			current.TcpHealthCheck.PortSpecification == desired.TcpHealthCheck.PortSpecification
+	}
+
+	if desired.HttpsHealthCheck != nil {
+		return current.HttpsHealthCheck != nil &&
+			current.HttpsHealthCheck.Port == desired.HttpsHealthCheck.Port &&
+			current.HttpsHealthCheck.PortSpecification == desired.HttpsHealthCheck.PortSpecification &&
+			current.HttpsHealthCheck.RequestPath == desired.HttpsHealthCheck.RequestPath
+	}
+
+	return true
+}
+
+// backendServiceMatches returns true if the backend service balances the same instance groups, with the same
+// health checks and settings, as the spec does.
+func backendServiceMatches(current, desired *compute.BackendService) bool {
+	if current.PortName != desired.PortName ||
+		current.Protocol != desired.Protocol ||
+		(desired.TimeoutSec != 0 && current.TimeoutSec != desired.TimeoutSec) {
+		return false
+	}
+
+	if len(current.Backends) != len(desired.Backends) {
+		return false
+	}
+
+	modes := make(map[string]string, len(current.Backends))
+	for _, backend := range current.Backends {
+		modes[resourcePath(backend.Group)] = backend.BalancingMode
+	}
+
+	for _, backend := range desired.Backends {
+		if mode, ok := modes[resourcePath(backend.Group)]; !ok || mode != backend.BalancingMode {
+			return false
+		}
+	}
+
+	if len(current.HealthChecks) != len(desired.HealthChecks) {
+		return false
+	}
+
+	healthChecks := sets.NewString()
+	for _, healthCheck := range current.HealthChecks {
+		healthChecks.Insert(resourcePath(healthCheck))
+	}
+
+	for _, healthCheck := range desired.HealthChecks {
+		if !healthChecks.Has(resourcePath(healthCheck)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyBackendService sets the fields of the spec managed by the controller on the backend service.
+func applyBackendService(current, desired *compute.BackendService) {
+	current.Backends = desired.Backends
+	current.HealthChecks = desired.HealthChecks
+	current.PortName = desired.PortName
+	current.Protocol = desired.Protocol
+	if desired.TimeoutSec != 0 {
+		current.TimeoutSec = desired.TimeoutSec
+	}
+}
+
+// securityPolicyRuleMatches returns true if the security policy rule has the same action for the same source ranges
+// as the spec does.
+func securityPolicyRuleMatches(current, desired *computebeta.SecurityPolicyRule) bool {
+	if current.Action != desired.Action || current.Match == nil || current.Match.Config == nil {
+		return false
+	}
+
+	return sets.NewString(current.Match.Config.SrcIpRanges...).Equal(sets.NewString(desired.Match.Config.SrcIpRanges...))
+}
+
+// resourcePath returns the path of a resource from its full or partial URL, starting with "projects/".
+func resourcePath(link string) string {
+	if i := strings.Index(link, "projects/"); i >= 0 {
+		return link[i:]
+	}
+
+	return link
+}
+
+// namedPortsMatch returns true if both lists hold the same named ports, in any order.
+func namedPortsMatch(current, desired []*compute.NamedPort) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	ports := make(map[string]int64, len(current))
+	for _, port := range current {
+		ports[port.Name] = port.Port
+	}
+
+	for _, port := range desired {
+		if p, ok := ports[port.Name]; !ok || p != port.Port {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,1023 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{
+		ClusterNetwork: &clusterv1.ClusterNetwork{},
+	},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name:                    pointer.String("my-network"),
+			LoadBalancerBackendPort: pointer.Int32(8443),
+		},
+	},
+	Status: infrav1.GCPClusterStatus{
+		FailureDomains: clusterv1.FailureDomains{
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+		},
+	},
+}
+
+func newClusterScope(t *testing.T, gcpCluster *infrav1.GCPCluster) *scope.ClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  gcpCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+func TestService_createOrGetHealthCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		objects    map[meta.Key]*cloud.MockHealthChecksObj
+		wantUpdate bool
+	}{
+		{
+			name:    "healthcheck does not exist (should create it on the backend port)",
+			objects: map[meta.Key]*cloud.MockHealthChecksObj{},
+		},
+		{
+			name: "healthcheck probes the default port (should update it)",
+			objects: map[meta.Key]*cloud.MockHealthChecksObj{
+				*meta.GlobalKey("my-cluster-apiserver"): {Obj: &compute.HealthCheck{
+					Name:           "my-cluster-apiserver",
+					Type:           "SSL",
+					SslHealthCheck: &compute.SSLHealthCheck{Port: 6443, PortSpecification: "USE_FIXED_PORT"},
+				}},
+			},
+			wantUpdate: true,
+		},
+		{
+			name: "healthcheck probes the backend port (should keep it)",
+			objects: map[meta.Key]*cloud.MockHealthChecksObj{
+				*meta.GlobalKey("my-cluster-apiserver"): {Obj: &compute.HealthCheck{
+					Name:               "my-cluster-apiserver",
+					Type:               "SSL",
+					SslHealthCheck:     &compute.SSLHealthCheck{Port: 8443, PortSpecification: "USE_FIXED_PORT"},
+					CheckIntervalSec:   10,
+					TimeoutSec:         5,
+					HealthyThreshold:   5,
+					UnhealthyThreshold: 3,
+				}},
+			},
+		},
+		{
+			name: "healthcheck with a different interval (should update it)",
+			objects: map[meta.Key]*cloud.MockHealthChecksObj{
+				*meta.GlobalKey("my-cluster-apiserver"): {Obj: &compute.HealthCheck{
+					Name:               "my-cluster-apiserver",
+					Type:               "SSL",
+					SslHealthCheck:     &compute.SSLHealthCheck{Port: 8443, PortSpecification: "USE_FIXED_PORT"},
+					CheckIntervalSec:   30,
+					TimeoutSec:         5,
+					HealthyThreshold:   5,
+					UnhealthyThreshold: 3,
+				}},
+			},
+			wantUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			updated := false
+			s := &Service{
+				scope: newClusterScope(t, fakeGCPCluster.DeepCopy()),
+				healthchecks: &cloud.MockHealthChecks{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       tt.objects,
+					UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.HealthCheck, m *cloud.MockHealthChecks) error {
+						updated = true
+						m.Objects[*key] = &cloud.MockHealthChecksObj{Obj: obj}
+						return nil
+					},
+				},
+			}
+
+			healthcheck, err := s.createOrGetHealthCheck(ctx)
+			if err != nil {
+				t.Fatalf("Service.createOrGetHealthCheck() error = %v", err)
+			}
+
+			if updated != tt.wantUpdate {
+				t.Errorf("Service.createOrGetHealthCheck() updated = %v, want %v", updated, tt.wantUpdate)
+			}
+			if healthcheck.SslHealthCheck == nil || healthcheck.SslHealthCheck.Port != 8443 {
+				t.Errorf("Service.createOrGetHealthCheck() SslHealthCheck = %v, want port 8443", healthcheck.SslHealthCheck)
+			}
+		})
+	}
+}
+
+func TestService_createOrGetHealthCheck_https(t *testing.T) {
+	ctx := context.TODO()
+	https := infrav1.HealthCheckProtocolHTTPS
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.HealthCheck = &infrav1.LoadBalancerHealthCheck{
+		Protocol:         &https,
+		CheckIntervalSec: pointer.Int64(5),
+		TimeoutSec:       pointer.Int64(2),
+	}
+	updated := false
+	s := &Service{
+		scope: newClusterScope(t, gcpCluster),
+		healthchecks: &cloud.MockHealthChecks{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects: map[meta.Key]*cloud.MockHealthChecksObj{
+				*meta.GlobalKey("my-cluster-apiserver"): {Obj: &compute.HealthCheck{
+					Name:               "my-cluster-apiserver",
+					Type:               "SSL",
+					SslHealthCheck:     &compute.SSLHealthCheck{Port: 8443, PortSpecification: "USE_FIXED_PORT"},
+					CheckIntervalSec:   10,
+					TimeoutSec:         5,
+					HealthyThreshold:   5,
+					UnhealthyThreshold: 3,
+				}},
+			},
+			UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.HealthCheck, m *cloud.MockHealthChecks) error {
+				updated = true
+				m.Objects[*key] = &cloud.MockHealthChecksObj{Obj: obj}
+				return nil
+			},
+		},
+	}
+
+	healthcheck, err := s.createOrGetHealthCheck(ctx)
+	if err != nil {
+		t.Fatalf("Service.createOrGetHealthCheck() error = %v", err)
+	}
+
+	if !updated {
+		t.Errorf("Service.createOrGetHealthCheck() did not update the SSL healthcheck in place")
+	}
+	want := &compute.HealthCheck{
+		Name:               "my-cluster-apiserver",
+		Type:               "HTTPS",
+		HttpsHealthCheck:   &compute.HTTPSHealthCheck{Port: 8443, PortSpecification: "USE_FIXED_PORT", RequestPath: "/readyz"},
+		CheckIntervalSec:   5,
+		TimeoutSec:         2,
+		HealthyThreshold:   5,
+		UnhealthyThreshold: 3,
+	}
+	if !healthCheckMatches(healthcheck, want) || healthcheck.SslHealthCheck != nil {
+		t.Errorf("Service.createOrGetHealthCheck() = %+v, want %+v", healthcheck, want)
+	}
+}
+
+func TestService_createOrGetInstanceGroups(t *testing.T) {
+	ctx := context.TODO()
+	var namedPorts *compute.InstanceGroupsSetNamedPortsRequest
+	s := &Service{
+		scope: newClusterScope(t, fakeGCPCluster.DeepCopy()),
+		instancegroups: &cloud.MockInstanceGroups{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects: map[meta.Key]*cloud.MockInstanceGroupsObj{
+				*meta.ZonalKey("my-cluster-apiserver-us-central1-a", "us-central1-a"): {Obj: &compute.InstanceGroup{
+					Name:        "my-cluster-apiserver-us-central1-a",
+					Fingerprint: "fingerprint",
+					NamedPorts:  []*compute.NamedPort{{Name: "apiserver", Port: 6443}},
+				}},
+			},
+			SetNamedPortsHook: func(_ context.Context, _ *meta.Key, req *compute.InstanceGroupsSetNamedPortsRequest, _ *cloud.MockInstanceGroups) error {
+				namedPorts = req
+				return nil
+			},
+		},
+	}
+
+	if _, err := s.createOrGetInstanceGroups(ctx); err != nil {
+		t.Fatalf("Service.createOrGetInstanceGroups() error = %v", err)
+	}
+
+	if namedPorts == nil || namedPorts.Fingerprint != "fingerprint" || !namedPortsMatch(namedPorts.NamedPorts, []*compute.NamedPort{{Name: "apiserver", Port: 8443}}) {
+		t.Errorf("Service.createOrGetInstanceGroups() named ports = %v, want apiserver:8443", namedPorts)
+	}
+}
+
+func TestService_reconcileIAPEndpoint(t *testing.T) {
+	ctx := context.TODO()
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.IAP = &infrav1.IAPSpec{InternalEndpoint: true}
+	clusterScope := newClusterScope(t, gcpCluster)
+	regionaddresses := &cloud.MockAddresses{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+		InsertHook: func(_ context.Context, key *meta.Key, obj *compute.Address, m *cloud.MockAddresses) (bool, error) {
+			obj.Address = "10.0.0.10"
+			obj.SelfLink = cloud.SelfLink(meta.VersionGA, "my-proj", "addresses", key)
+			m.Objects[*key] = &cloud.MockAddressesObj{Obj: obj}
+			return true, nil
+		},
+	}
+	regionforwardingrules := &cloud.MockForwardingRules{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+	}
+	s := &Service{
+		scope:           clusterScope,
+		regionaddresses: regionaddresses,
+		regionbackendservices: &cloud.MockRegionBackendServices{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+		},
+		regionforwardingrules: regionforwardingrules,
+		regionhealthchecks: &cloud.MockRegionHealthChecks{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+		},
+	}
+
+	instancegroups := []*compute.InstanceGroup{{
+		Name:     "my-cluster-apiserver-us-central1-a",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a",
+	}}
+	if err := s.reconcileIAPEndpoint(ctx, instancegroups); err != nil {
+		t.Fatalf("Service.reconcileIAPEndpoint() error = %v", err)
+	}
+
+	forwarding, err := regionforwardingrules.Get(ctx, meta.RegionalKey("my-cluster-apiserver-internal", "us-central1"))
+	if err != nil {
+		t.Fatalf("Service.reconcileIAPEndpoint() forwarding rule not created: %v", err)
+	}
+	if forwarding.LoadBalancingScheme != "INTERNAL" || len(forwarding.Ports) != 1 || forwarding.Ports[0] != "8443" {
+		t.Errorf("Service.reconcileIAPEndpoint() forwarding rule = %s %v, want INTERNAL [8443]", forwarding.LoadBalancingScheme, forwarding.Ports)
+	}
+
+	status := clusterScope.IAPEndpoint()
+	if status == nil || pointer.StringDeref(status.Host, "") != "10.0.0.10" {
+		t.Fatalf("Service.reconcileIAPEndpoint() status = %v, want host 10.0.0.10", status)
+	}
+	if gcpCluster.Spec.ControlPlaneEndpoint.Host == "10.0.0.10" {
+		t.Errorf("Service.reconcileIAPEndpoint() replaced the control plane endpoint")
+	}
+
+	if err := s.deleteIAPEndpoint(ctx); err != nil {
+		t.Fatalf("Service.deleteIAPEndpoint() error = %v", err)
+	}
+	if len(regionaddresses.Objects) != 0 || len(regionforwardingrules.Objects) != 0 {
+		t.Errorf("Service.deleteIAPEndpoint() left the address or forwarding rule behind")
+	}
+	if clusterScope.IAPEndpoint() != nil {
+		t.Errorf("Service.deleteIAPEndpoint() status = %v, want nil", clusterScope.IAPEndpoint())
+	}
+}
+
+func TestService_Reconcile_iapEndpoint(t *testing.T) {
+	groupLink := "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a"
+	backendService := func(name, scheme, mode string) *compute.BackendService {
+		return &compute.BackendService{
+			Name:                name,
+			LoadBalancingScheme: scheme,
+			Protocol:            "TCP",
+			Backends:            []*compute.Backend{{BalancingMode: mode, Group: groupLink}},
+			SelfLink:            "https://www.googleapis.com/compute/v1/projects/my-proj/backendServices/" + name,
+		}
+	}
+
+	tests := []struct {
+		name              string
+		internalEndpoint  bool
+		status            *infrav1.IAPEndpointStatus
+		backendservices   map[meta.Key]*cloud.MockBackendServicesObj
+		regionbackendsvcs map[meta.Key]*cloud.MockRegionBackendServicesObj
+		wantGlobalMode    string
+		wantInternal      bool
+		wantEvents        []string
+	}{
+		{
+			name:              "internal endpoint enabled (should balance the instance groups by connection in both backend services)",
+			internalEndpoint:  true,
+			backendservices:   map[meta.Key]*cloud.MockBackendServicesObj{},
+			regionbackendsvcs: map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			wantGlobalMode:    "CONNECTION",
+			wantInternal:      true,
+			wantEvents:        []string{"insert my-cluster-apiserver", "insert my-cluster-apiserver-internal"},
+		},
+		{
+			name:             "internal endpoint disabled (should delete it before balancing the instance groups by utilization)",
+			internalEndpoint: false,
+			status:           &infrav1.IAPEndpointStatus{Host: pointer.String("10.0.0.10")},
+			backendservices: map[meta.Key]*cloud.MockBackendServicesObj{
+				*meta.GlobalKey("my-cluster-apiserver"): {Obj: backendService("my-cluster-apiserver", "EXTERNAL", "CONNECTION")},
+			},
+			regionbackendsvcs: map[meta.Key]*cloud.MockRegionBackendServicesObj{
+				*meta.RegionalKey("my-cluster-apiserver-internal", "us-central1"): {Obj: backendService("my-cluster-apiserver-internal", "INTERNAL", "CONNECTION")},
+			},
+			wantGlobalMode: "UTILIZATION",
+			wantInternal:   false,
+			wantEvents:     []string{"delete my-cluster-apiserver-internal", "update my-cluster-apiserver"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.IAP = &infrav1.IAPSpec{InternalEndpoint: tt.internalEndpoint}
+			gcpCluster.Status.IAPEndpoint = tt.status
+			clusterScope := newClusterScope(t, gcpCluster)
+
+			var events []string
+			backendservices := &cloud.MockBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       tt.backendservices,
+				InsertHook: func(_ context.Context, key *meta.Key, _ *compute.BackendService, _ *cloud.MockBackendServices) (bool, error) {
+					events = append(events, "insert "+key.Name)
+					return false, nil
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.BackendService, m *cloud.MockBackendServices) error {
+					events = append(events, "update "+key.Name)
+					m.Objects[*key] = &cloud.MockBackendServicesObj{Obj: obj}
+					return nil
+				},
+			}
+			regionbackendservices := &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       tt.regionbackendsvcs,
+				InsertHook: func(_ context.Context, key *meta.Key, _ *compute.BackendService, _ *cloud.MockRegionBackendServices) (bool, error) {
+					events = append(events, "insert "+key.Name)
+					return false, nil
+				},
+				DeleteHook: func(_ context.Context, key *meta.Key, _ *cloud.MockRegionBackendServices) (bool, error) {
+					events = append(events, "delete "+key.Name)
+					return false, nil
+				},
+			}
+			s := &Service{
+				scope: clusterScope,
+				addresses: &cloud.MockGlobalAddresses{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockGlobalAddressesObj{},
+				},
+				backendservices: backendservices,
+				forwardingrules: &cloud.MockGlobalForwardingRules{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockGlobalForwardingRulesObj{},
+				},
+				healthchecks: &cloud.MockHealthChecks{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockHealthChecksObj{},
+				},
+				instancegroups: &cloud.MockInstanceGroups{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects: map[meta.Key]*cloud.MockInstanceGroupsObj{
+						*meta.ZonalKey("my-cluster-apiserver-us-central1-a", "us-central1-a"): {Obj: &compute.InstanceGroup{
+							Name:       "my-cluster-apiserver-us-central1-a",
+							NamedPorts: []*compute.NamedPort{{Name: "apiserver", Port: 8443}},
+							SelfLink:   groupLink,
+						}},
+					},
+				},
+				targettcpproxies: &cloud.MockTargetTcpProxies{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockTargetTcpProxiesObj{},
+				},
+				regionaddresses: &cloud.MockAddresses{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+				},
+				regionbackendservices: regionbackendservices,
+				regionforwardingrules: &cloud.MockForwardingRules{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+				},
+				regionhealthchecks: &cloud.MockRegionHealthChecks{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+				},
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(events, tt.wantEvents) {
+				t.Errorf("Service.Reconcile() backend service events = %v, want %v", events, tt.wantEvents)
+			}
+
+			global, err := backendservices.Get(ctx, meta.GlobalKey("my-cluster-apiserver"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mode := global.Backends[0].BalancingMode; mode != tt.wantGlobalMode {
+				t.Errorf("Service.Reconcile() global balancing mode = %s, want %s", mode, tt.wantGlobalMode)
+			}
+
+			internal, err := regionbackendservices.Get(ctx, meta.RegionalKey("my-cluster-apiserver-internal", "us-central1"))
+			if (err == nil) != tt.wantInternal {
+				t.Fatalf("Service.Reconcile() internal backend service exists = %v, want %v", err == nil, tt.wantInternal)
+			}
+			if tt.wantInternal && internal.Backends[0].BalancingMode != global.Backends[0].BalancingMode {
+				t.Errorf("Service.Reconcile() internal balancing mode = %s, want %s", internal.Backends[0].BalancingMode, global.Backends[0].BalancingMode)
+			}
+		})
+	}
+}
+
+func TestService_reconcileRegional(t *testing.T) {
+	ctx := context.TODO()
+	regionalExternal := infrav1.RegionalExternal
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.LoadBalancerType = &regionalExternal
+	clusterScope := newClusterScope(t, gcpCluster)
+	regionforwardingrules := &cloud.MockForwardingRules{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+	}
+	s := &Service{
+		scope: clusterScope,
+		regionaddresses: &cloud.MockAddresses{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			InsertHook: func(_ context.Context, key *meta.Key, obj *compute.Address, m *cloud.MockAddresses) (bool, error) {
+				obj.Address = "203.0.113.10"
+				m.Objects[*key] = &cloud.MockAddressesObj{Obj: obj}
+				return true, nil
+			},
+		},
+		regionbackendservices: &cloud.MockRegionBackendServices{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+		},
+		regionforwardingrules: regionforwardingrules,
+		regionhealthchecks: &cloud.MockRegionHealthChecks{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+		},
+	}
+
+	if err := s.reconcileRegional(ctx, []*compute.InstanceGroup{{Name: "my-cluster-apiserver-us-central1-a"}}); err != nil {
+		t.Fatalf("Service.reconcileRegional() error = %v", err)
+	}
+
+	forwarding, err := regionforwardingrules.Get(ctx, meta.RegionalKey("my-cluster-apiserver", "us-central1"))
+	if err != nil {
+		t.Fatalf("Service.reconcileRegional() forwarding rule not created: %v", err)
+	}
+	if forwarding.LoadBalancingScheme != "EXTERNAL" || len(forwarding.Ports) != 1 || forwarding.Ports[0] != "8443" {
+		t.Errorf("Service.reconcileRegional() forwarding rule = %s %v, want EXTERNAL [8443]", forwarding.LoadBalancingScheme, forwarding.Ports)
+	}
+
+	endpoint := clusterScope.ControlPlaneEndpoint()
+	if endpoint.Host != "203.0.113.10" || endpoint.Port != 8443 {
+		t.Errorf("Service.reconcileRegional() control plane endpoint = %s:%d, want 203.0.113.10:8443", endpoint.Host, endpoint.Port)
+	}
+	if clusterScope.Network().APIServerForwardingRule == nil || clusterScope.Network().APIServerBackendService == nil {
+		t.Errorf("Service.reconcileRegional() did not record the forwarding rule and backend service in the network status")
+	}
+}
+
+func TestService_Reconcile_internal(t *testing.T) {
+	ctx := context.TODO()
+	internal := infrav1.Internal
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.LoadBalancerType = &internal
+	gcpCluster.Spec.Network.Subnets = infrav1.Subnets{{Name: "my-subnet", CidrBlock: "10.0.0.0/20"}}
+	clusterScope := newClusterScope(t, gcpCluster)
+	instancegroups := &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockInstanceGroupsObj{},
+	}
+	regionaddresses := &cloud.MockAddresses{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+		InsertHook: func(_ context.Context, key *meta.Key, obj *compute.Address, m *cloud.MockAddresses) (bool, error) {
+			obj.Address = "10.0.0.10"
+			obj.SelfLink = cloud.SelfLink(meta.VersionGA, "my-proj", "addresses", key)
+			m.Objects[*key] = &cloud.MockAddressesObj{Obj: obj}
+			return true, nil
+		},
+	}
+	regionbackendservices := &cloud.MockRegionBackendServices{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+	}
+	regionforwardingrules := &cloud.MockForwardingRules{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+	}
+	regionhealthchecks := &cloud.MockRegionHealthChecks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+	}
+	s := &Service{
+		scope:                 clusterScope,
+		instancegroups:        instancegroups,
+		regionaddresses:       regionaddresses,
+		regionbackendservices: regionbackendservices,
+		regionforwardingrules: regionforwardingrules,
+		regionhealthchecks:    regionhealthchecks,
+	}
+
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatalf("Service.Reconcile() error = %v", err)
+	}
+
+	addr, err := regionaddresses.Get(ctx, meta.RegionalKey("my-cluster-apiserver", "us-central1"))
+	if err != nil {
+		t.Fatalf("Service.Reconcile() address not created: %v", err)
+	}
+	if addr.AddressType != "INTERNAL" || addr.Purpose != "GCE_ENDPOINT" || addr.Subnetwork != "projects/my-proj/regions/us-central1/subnetworks/my-subnet" {
+		t.Errorf("Service.Reconcile() address = %s %s %s, want INTERNAL GCE_ENDPOINT in my-subnet", addr.AddressType, addr.Purpose, addr.Subnetwork)
+	}
+
+	backendsvc, err := regionbackendservices.Get(ctx, meta.RegionalKey("my-cluster-apiserver", "us-central1"))
+	if err != nil {
+		t.Fatalf("Service.Reconcile() backend service not created: %v", err)
+	}
+	if backendsvc.LoadBalancingScheme != "INTERNAL" || len(backendsvc.Backends) != 1 || backendsvc.Backends[0].BalancingMode != "CONNECTION" {
+		t.Errorf("Service.Reconcile() backend service = %s %v, want INTERNAL with one CONNECTION backend", backendsvc.LoadBalancingScheme, backendsvc.Backends)
+	}
+
+	forwarding, err := regionforwardingrules.Get(ctx, meta.RegionalKey("my-cluster-apiserver", "us-central1"))
+	if err != nil {
+		t.Fatalf("Service.Reconcile() forwarding rule not created: %v", err)
+	}
+	if forwarding.LoadBalancingScheme != "INTERNAL" || len(forwarding.Ports) != 1 || forwarding.Ports[0] != "8443" || forwarding.Subnetwork == "" {
+		t.Errorf("Service.Reconcile() forwarding rule = %s %v %s, want INTERNAL [8443] in a subnetwork", forwarding.LoadBalancingScheme, forwarding.Ports, forwarding.Subnetwork)
+	}
+
+	endpoint := clusterScope.ControlPlaneEndpoint()
+	if endpoint.Host != "10.0.0.10" || endpoint.Port != 8443 {
+		t.Errorf("Service.Reconcile() control plane endpoint = %s:%d, want 10.0.0.10:8443", endpoint.Host, endpoint.Port)
+	}
+
+	if err := s.Delete(ctx); err != nil {
+		t.Fatalf("Service.Delete() error = %v", err)
+	}
+
+	if len(regionaddresses.Objects) != 0 || len(regionbackendservices.Objects) != 0 || len(regionforwardingrules.Objects) != 0 ||
+		len(regionhealthchecks.Objects) != 0 || len(instancegroups.Objects) != 0 {
+		t.Errorf("Service.Delete() left internal load balancer resources behind")
+	}
+	network := clusterScope.Network()
+	if network.APIServerAddress != nil || network.APIServerBackendService != nil || network.APIServerForwardingRule != nil || network.APIServerHealthCheck != nil {
+		t.Errorf("Service.Delete() did not clear the network status")
+	}
+}
+
+func TestService_createOrGetBackendService(t *testing.T) {
+	groupA := &compute.InstanceGroup{SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a"}
+	groupB := &compute.InstanceGroup{SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-b/instanceGroups/my-cluster-apiserver-us-central1-b"}
+	groupC := &compute.InstanceGroup{SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-c/instanceGroups/my-cluster-apiserver-us-central1-c"}
+	healthcheck := &compute.HealthCheck{SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/global/healthChecks/my-cluster-apiserver"}
+	backendService := func(timeout int64, groups ...*compute.InstanceGroup) *compute.BackendService {
+		backends := []*compute.Backend{}
+		for _, group := range groups {
+			backends = append(backends, &compute.Backend{BalancingMode: "UTILIZATION", Group: group.SelfLink})
+		}
+
+		return &compute.BackendService{
+			Name:                "my-cluster-apiserver",
+			LoadBalancingScheme: "EXTERNAL",
+			PortName:            "apiserver",
+			Protocol:            "TCP",
+			TimeoutSec:          timeout,
+			Backends:            backends,
+			HealthChecks:        []string{"projects/my-proj/global/healthChecks/my-cluster-apiserver"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		current    *compute.BackendService
+		wantUpdate bool
+	}{
+		{
+			name:    "backend service balancing the same groups in another order (should keep it)",
+			current: backendService(600, groupB, groupA),
+		},
+		{
+			name:       "backend service balancing a removed zone (should update it)",
+			current:    backendService(600, groupA, groupC),
+			wantUpdate: true,
+		},
+		{
+			name:       "backend service with another timeout (should update it)",
+			current:    backendService(30, groupA, groupB),
+			wantUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			var updated *compute.BackendService
+			s := &Service{
+				scope: newClusterScope(t, fakeGCPCluster.DeepCopy()),
+				backendservices: &cloud.MockBackendServices{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects: map[meta.Key]*cloud.MockBackendServicesObj{
+						*meta.GlobalKey("my-cluster-apiserver"): {Obj: tt.current},
+					},
+					UpdateHook: func(_ context.Context, _ *meta.Key, obj *compute.BackendService, _ *cloud.MockBackendServices) error {
+						updated = obj
+						return nil
+					},
+				},
+			}
+
+			if _, err := s.createOrGetBackendService(ctx, []*compute.InstanceGroup{groupA, groupB}, healthcheck); err != nil {
+				t.Fatalf("Service.createOrGetBackendService() error = %v", err)
+			}
+
+			if (updated != nil) != tt.wantUpdate {
+				t.Fatalf("Service.createOrGetBackendService() updated = %v, want %v", updated != nil, tt.wantUpdate)
+			}
+			if updated != nil && !backendServiceMatches(updated, backendService(600, groupA, groupB)) {
+				t.Errorf("Service.createOrGetBackendService() updated backend service = %+v", updated)
+			}
+		})
+	}
+}
+
+func TestService_deleteStaleInstanceGroups(t *testing.T) {
+	ctx := context.TODO()
+	clusterScope := newClusterScope(t, fakeGCPCluster.DeepCopy())
+	clusterScope.Network().APIServerInstanceGroups = map[string]string{
+		"us-central1-a": "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a",
+		"us-central1-b": "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-b/instanceGroups/my-cluster-apiserver-us-central1-b",
+	}
+	instancegroups := &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects: map[meta.Key]*cloud.MockInstanceGroupsObj{
+			*meta.ZonalKey("my-cluster-apiserver-us-central1-a", "us-central1-a"): {Obj: &compute.InstanceGroup{Name: "my-cluster-apiserver-us-central1-a"}},
+			*meta.ZonalKey("my-cluster-apiserver-us-central1-b", "us-central1-b"): {Obj: &compute.InstanceGroup{Name: "my-cluster-apiserver-us-central1-b"}},
+		},
+	}
+	s := &Service{
+		scope:          clusterScope,
+		instancegroups: instancegroups,
+	}
+
+	if err := s.deleteStaleInstanceGroups(ctx); err != nil {
+		t.Fatalf("Service.deleteStaleInstanceGroups() error = %v", err)
+	}
+
+	if _, ok := instancegroups.Objects[*meta.ZonalKey("my-cluster-apiserver-us-central1-b", "us-central1-b")]; ok {
+		t.Errorf("Service.deleteStaleInstanceGroups() kept the instance group of the removed zone")
+	}
+	if _, ok := instancegroups.Objects[*meta.ZonalKey("my-cluster-apiserver-us-central1-a", "us-central1-a")]; !ok {
+		t.Errorf("Service.deleteStaleInstanceGroups() deleted the instance group of a failure domain")
+	}
+	if _, ok := clusterScope.Network().APIServerInstanceGroups["us-central1-b"]; ok {
+		t.Errorf("Service.deleteStaleInstanceGroups() kept the removed zone in the network status")
+	}
+}
+
+func TestService_reservedAddress(t *testing.T) {
+	regionalExternal := infrav1.RegionalExternal
+	tests := []struct {
+		name             string
+		loadBalancerType *infrav1.LoadBalancerType
+		reservedAddress  string
+	}{
+		{
+			name:            "global address referenced by name",
+			reservedAddress: "my-address",
+		},
+		{
+			name:            "global address referenced by IP address",
+			reservedAddress: "203.0.113.20",
+		},
+		{
+			name:             "regional address referenced by IP address",
+			loadBalancerType: &regionalExternal,
+			reservedAddress:  "203.0.113.20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.LoadBalancer.LoadBalancerType = tt.loadBalancerType
+			gcpCluster.Spec.LoadBalancer.ReservedAddress = pointer.String(tt.reservedAddress)
+			clusterScope := newClusterScope(t, gcpCluster)
+			address := func(key *meta.Key) *compute.Address {
+				return &compute.Address{
+					Name:     key.Name,
+					Address:  "203.0.113.20",
+					SelfLink: cloud.SelfLink(meta.VersionGA, "my-proj", "addresses", key),
+				}
+			}
+			globalKey := meta.GlobalKey("my-address")
+			regionalKey := meta.RegionalKey("my-address", "us-central1")
+			addresses := &cloud.MockGlobalAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockGlobalAddressesObj{
+					*globalKey: {Obj: address(globalKey)},
+				},
+			}
+			regionaddresses := &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockAddressesObj{
+					*regionalKey: {Obj: address(regionalKey)},
+				},
+			}
+			s := &Service{
+				scope:           clusterScope,
+				addresses:       addresses,
+				regionaddresses: regionaddresses,
+			}
+
+			var addr *compute.Address
+			var err error
+			if tt.loadBalancerType != nil {
+				addr, err = s.createOrGetRegionalAddress(ctx)
+			} else {
+				addr, err = s.createOrGetAddress(ctx)
+			}
+			if err != nil {
+				t.Fatalf("Service.createOrGetAddress() error = %v", err)
+			}
+
+			if addr.Name != "my-address" || gcpCluster.Spec.ControlPlaneEndpoint.Host != "203.0.113.20" {
+				t.Errorf("Service.createOrGetAddress() = %s, endpoint %s, want my-address, endpoint 203.0.113.20", addr.Name, gcpCluster.Spec.ControlPlaneEndpoint.Host)
+			}
+			if len(addresses.Objects) != 1 || len(regionaddresses.Objects) != 1 {
+				t.Errorf("Service.createOrGetAddress() reserved another address")
+			}
+
+			if tt.loadBalancerType != nil {
+				err = s.deleteRegionalAddress(ctx)
+			} else {
+				err = s.deleteAddress(ctx)
+			}
+			if err != nil {
+				t.Fatalf("Service.deleteAddress() error = %v", err)
+			}
+
+			if len(addresses.Objects) != 1 || len(regionaddresses.Objects) != 1 {
+				t.Errorf("Service.deleteAddress() released the reserved address")
+			}
+			if clusterScope.Network().APIServerAddress != nil {
+				t.Errorf("Service.deleteAddress() kept the address in the network status")
+			}
+		})
+	}
+}
+
+func TestService_reconcileSecurityPolicy(t *testing.T) {
+	inlineKey := meta.GlobalKey("my-cluster-apiserver")
+	inlineLink := cloud.SelfLink(meta.VersionBeta, "my-proj", "securityPolicies", inlineKey)
+	inlinePolicy := func(ranges ...string) *computebeta.SecurityPolicy {
+		return &computebeta.SecurityPolicy{
+			Name:        inlineKey.Name,
+			Description: infrav1.ClusterTagKey("my-cluster"),
+			SelfLink:    inlineLink,
+			Rules: []*computebeta.SecurityPolicyRule{
+				{
+					Action:   "allow",
+					Priority: 0,
+					Match: &computebeta.SecurityPolicyRuleMatcher{
+						VersionedExpr: "SRC_IPS_V1",
+						Config:        &computebeta.SecurityPolicyRuleMatcherConfig{SrcIpRanges: ranges},
+					},
+				},
+			},
+		}
+	}
+	sharedKey := meta.GlobalKey("shared-policy")
+	sharedLink := cloud.SelfLink(meta.VersionBeta, "my-proj", "securityPolicies", sharedKey)
+
+	tests := []struct {
+		name           string
+		securityPolicy *infrav1.SecurityPolicySpec
+		attached       *string
+		objects        map[meta.Key]*cloud.MockSecurityPoliciesObj
+		wantPatch      bool
+		wantAttach     *string
+		wantStatus     string
+		wantPolicies   []string
+	}{
+		{
+			name:           "allow-list without security policy (should create and attach it)",
+			securityPolicy: &infrav1.SecurityPolicySpec{AllowedSourceRanges: []string{"203.0.113.0/24"}},
+			objects:        map[meta.Key]*cloud.MockSecurityPoliciesObj{},
+			wantAttach:     pointer.String(inlineLink),
+			wantStatus:     inlineLink,
+			wantPolicies:   []string{inlineKey.Name},
+		},
+		{
+			name:           "allow-list with other ranges (should update the allow rule)",
+			securityPolicy: &infrav1.SecurityPolicySpec{AllowedSourceRanges: []string{"203.0.113.0/24", "198.51.100.0/24"}},
+			attached:       pointer.String(inlineLink),
+			objects: map[meta.Key]*cloud.MockSecurityPoliciesObj{
+				*inlineKey: {Obj: inlinePolicy("203.0.113.0/24")},
+			},
+			wantPatch:    true,
+			wantStatus:   inlineLink,
+			wantPolicies: []string{inlineKey.Name},
+		},
+		{
+			name:           "referenced security policy replacing the allow-list (should attach it and delete the allow-list)",
+			securityPolicy: &infrav1.SecurityPolicySpec{Name: pointer.String(sharedKey.Name)},
+			attached:       pointer.String(inlineLink),
+			objects: map[meta.Key]*cloud.MockSecurityPoliciesObj{
+				*inlineKey: {Obj: inlinePolicy("203.0.113.0/24")},
+				*sharedKey: {Obj: &computebeta.SecurityPolicy{Name: sharedKey.Name, SelfLink: sharedLink}},
+			},
+			wantAttach:   pointer.String(sharedLink),
+			wantStatus:   sharedLink,
+			wantPolicies: []string{sharedKey.Name},
+		},
+		{
+			name:     "security policy removed from the spec (should detach it and keep the referenced policy)",
+			attached: pointer.String(sharedLink),
+			objects: map[meta.Key]*cloud.MockSecurityPoliciesObj{
+				*sharedKey: {Obj: &computebeta.SecurityPolicy{Name: sharedKey.Name, SelfLink: sharedLink}},
+			},
+			wantAttach:   pointer.String(""),
+			wantPolicies: []string{sharedKey.Name},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.LoadBalancer.SecurityPolicy = tt.securityPolicy
+			gcpCluster.Status.Network.APIServerSecurityPolicy = tt.attached
+			backendsvc := &compute.BackendService{Name: "my-cluster-apiserver", SecurityPolicy: pointer.StringDeref(tt.attached, "")}
+			var attached *compute.SecurityPolicyReference
+			patched := false
+			securitypolicies := &cloud.MockBetaSecurityPolicies{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       tt.objects,
+				PatchRuleHook: func(_ context.Context, _ *meta.Key, rule *computebeta.SecurityPolicyRule, _ *cloud.MockBetaSecurityPolicies) error {
+					patched = rule.Priority == 0 && len(rule.Match.Config.SrcIpRanges) == 2
+					return nil
+				},
+			}
+			s := &Service{
+				scope: newClusterScope(t, gcpCluster),
+				backendservices: &cloud.MockBackendServices{
+					ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+					Objects: map[meta.Key]*cloud.MockBackendServicesObj{
+						*meta.GlobalKey(backendsvc.Name): {Obj: backendsvc},
+					},
+					SetSecurityPolicyHook: func(_ context.Context, _ *meta.Key, ref *compute.SecurityPolicyReference, _ *cloud.MockBackendServices) error {
+						attached = ref
+						return nil
+					},
+				},
+				securitypolicies: securitypolicies,
+			}
+
+			if err := s.reconcileSecurityPolicy(ctx, backendsvc); err != nil {
+				t.Fatalf("Service.reconcileSecurityPolicy() error = %v", err)
+			}
+
+			if patched != tt.wantPatch {
+				t.Errorf("Service.reconcileSecurityPolicy() patched = %v, want %v", patched, tt.wantPatch)
+			}
+			if (attached == nil) != (tt.wantAttach == nil) || (attached != nil && attached.SecurityPolicy != *tt.wantAttach) {
+				t.Errorf("Service.reconcileSecurityPolicy() attached = %v, want %v", attached, tt.wantAttach)
+			}
+			if got := pointer.StringDeref(gcpCluster.Status.Network.APIServerSecurityPolicy, ""); got != tt.wantStatus {
+				t.Errorf("Service.reconcileSecurityPolicy() status = %q, want %q", got, tt.wantStatus)
+			}
+
+			policies := []string{}
+			for key := range securitypolicies.Objects {
+				policies = append(policies, key.Name)
+			}
+			if !reflect.DeepEqual(policies, tt.wantPolicies) {
+				t.Errorf("Service.reconcileSecurityPolicy() security policies = %v, want %v", policies, tt.wantPolicies)
+			}
+		})
+	}
+}
+
+func TestService_reconcileAdditionalPorts(t *testing.T) {
+	ctx := context.TODO()
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.AdditionalPorts = []infrav1.LoadBalancerPort{{Name: "konnectivity", Port: 8132}}
+	clusterScope := newClusterScope(t, gcpCluster)
+	clusterScope.Network().AdditionalPortForwardingRules = map[string]string{
+		"registry": "https://www.googleapis.com/compute/v1/projects/my-proj/global/forwardingRules/my-cluster-registry",
+	}
+	backendservices := &cloud.MockBackendServices{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockBackendServicesObj{},
+	}
+	forwardingrules := &cloud.MockGlobalForwardingRules{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects: map[meta.Key]*cloud.MockGlobalForwardingRulesObj{
+			*meta.GlobalKey("my-cluster-registry"): {Obj: &compute.ForwardingRule{Name: "my-cluster-registry"}},
+		},
+	}
+	healthchecks := &cloud.MockHealthChecks{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockHealthChecksObj{},
+	}
+	s := &Service{
+		scope:           clusterScope,
+		backendservices: backendservices,
+		forwardingrules: forwardingrules,
+		healthchecks:    healthchecks,
+		targettcpproxies: &cloud.MockTargetTcpProxies{
+			ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+			Objects:       map[meta.Key]*cloud.MockTargetTcpProxiesObj{},
+		},
+	}
+	addr := &compute.Address{SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/global/addresses/my-cluster-apiserver"}
+
+	if err := s.reconcileAdditionalPorts(ctx, []*compute.InstanceGroup{{Name: "my-cluster-apiserver-us-central1-a"}}, addr); err != nil {
+		t.Fatalf("Service.reconcileAdditionalPorts() error = %v", err)
+	}
+
+	healthcheck, err := healthchecks.Get(ctx, meta.GlobalKey("my-cluster-konnectivity"))
+	if err != nil {
+		t.Fatalf("Service.reconcileAdditionalPorts() health check not created: %v", err)
+	}
+	if healthcheck.TcpHealthCheck == nil || healthcheck.TcpHealthCheck.Port != 8132 {
+		t.Errorf("Service.reconcileAdditionalPorts() health check = %+v, want TCP port 8132", healthcheck.TcpHealthCheck)
+	}
+
+	backendsvc, err := backendservices.Get(ctx, meta.GlobalKey("my-cluster-konnectivity"))
+	if err != nil {
+		t.Fatalf("Service.reconcileAdditionalPorts() backend service not created: %v", err)
+	}
+	if backendsvc.PortName != "konnectivity" {
+		t.Errorf("Service.reconcileAdditionalPorts() backend service port name = %s, want konnectivity", backendsvc.PortName)
+	}
+
+	forwarding, err := forwardingrules.Get(ctx, meta.GlobalKey("my-cluster-konnectivity"))
+	if err != nil {
+		t.Fatalf("Service.reconcileAdditionalPorts() forwarding rule not created: %v", err)
+	}
+	if forwarding.PortRange != "8132-8132" || forwarding.IPAddress != addr.SelfLink {
+		t.Errorf("Service.reconcileAdditionalPorts() forwarding rule = %s %s, want 8132-8132 on the API server address", forwarding.PortRange, forwarding.IPAddress)
+	}
+
+	if _, ok := forwardingrules.Objects[*meta.GlobalKey("my-cluster-registry")]; ok {
+		t.Errorf("Service.reconcileAdditionalPorts() kept the forwarding rule of the removed port")
+	}
+	if _, ok := clusterScope.Network().AdditionalPortForwardingRules["registry"]; ok {
+		t.Errorf("Service.reconcileAdditionalPorts() kept the removed port in the network status")
+	}
+	if clusterScope.Network().AdditionalPortForwardingRules["konnectivity"] != forwarding.SelfLink {
+		t.Errorf("Service.reconcileAdditionalPorts() did not record the forwarding rule in the network status")
+	}
+}
@@ -21,17 +21,35 @@ import (
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	computebeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
 type addressesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Address, error)
+	List(ctx context.Context, fl *filter.F) ([]*compute.Address, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.Address) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type regionaddressesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Address, error)
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.Address, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.Address) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
 type backendservicesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.BackendService, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.BackendService) error
+	Update(context.Context, *meta.Key, *compute.BackendService) error
+	SetSecurityPolicy(context.Context, *meta.Key, *compute.SecurityPolicyReference) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type regionbackendservicesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.BackendService, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.BackendService) error
 	Update(context.Context, *meta.Key, *compute.BackendService) error
@@ -47,6 +65,7 @@ type forwardingrulesInterface interface {
 type healthchecksInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.HealthCheck, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) error
+	Update(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
@@ -54,6 +73,15 @@ type instancegroupsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroup, error)
 	List(ctx context.Context, zone string, fl *filter.F) ([]*compute.InstanceGroup, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroup) error
+	SetNamedPorts(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsSetNamedPortsRequest) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type securitypoliciesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*computebeta.SecurityPolicy, error)
+	Insert(ctx context.Context, key *meta.Key, obj *computebeta.SecurityPolicy) error
+	AddRule(context.Context, *meta.Key, *computebeta.SecurityPolicyRule) error
+	PatchRule(context.Context, *meta.Key, *computebeta.SecurityPolicyRule) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
@@ -72,6 +100,22 @@ type Scope interface {
 	HealthCheckSpec() *compute.HealthCheck
 	InstanceGroupSpec(zone string) *compute.InstanceGroup
 	TargetTCPProxySpec() *compute.TargetTcpProxy
+	LoadBalancerType() infrav1.LoadBalancerType
+	LoadBalancerReservedAddress() string
+	SecurityPolicyName() string
+	SecurityPolicySpec() *computebeta.SecurityPolicy
+	AdditionalPorts() []infrav1.LoadBalancerPort
+	AdditionalPortHealthCheckSpec(port infrav1.LoadBalancerPort) *compute.HealthCheck
+	AdditionalPortBackendServiceSpec(port infrav1.LoadBalancerPort) *compute.BackendService
+	AdditionalPortTargetTCPProxySpec(port infrav1.LoadBalancerPort) *compute.TargetTcpProxy
+	AdditionalPortForwardingRuleSpec(port infrav1.LoadBalancerPort) *compute.ForwardingRule
+	IAPEndpointEnabled() bool
+	IAPEndpointAddressSpec() *compute.Address
+	IAPEndpointBackendServiceSpec() *compute.BackendService
+	IAPEndpointForwardingRuleSpec() *compute.ForwardingRule
+	IAPEndpointHealthCheckSpec() *compute.HealthCheck
+	IAPEndpoint() *infrav1.IAPEndpointStatus
+	SetIAPEndpoint(status *infrav1.IAPEndpointStatus)
 }
 
 // Service implements loadbalancers reconciler.
@@ -82,7 +126,14 @@ type Service struct {
 	forwardingrules  forwardingrulesInterface
 	healthchecks     healthchecksInterface
 	instancegroups   instancegroupsInterface
+	securitypolicies securitypoliciesInterface
 	targettcpproxies targettcpproxiesInterface
+
+	// regional clients used by the internal load balancer and the internal API server endpoint.
+	regionaddresses       regionaddressesInterface
+	regionbackendservices regionbackendservicesInterface
+	regionforwardingrules forwardingrulesInterface
+	regionhealthchecks    healthchecksInterface
 }
 
 var _ cloud.Reconciler = &Service{}
@@ -96,6 +147,12 @@ func New(scope Scope) *Service {
 		forwardingrules:  scope.Cloud().GlobalForwardingRules(),
 		healthchecks:     scope.Cloud().HealthChecks(),
 		instancegroups:   scope.Cloud().InstanceGroups(),
+		securitypolicies: scope.Cloud().BetaSecurityPolicies(),
 		targettcpproxies: scope.Cloud().TargetTcpProxies(),
+
+		regionaddresses:       scope.Cloud().Addresses(),
+		regionbackendservices: scope.Cloud().RegionBackendServices(),
+		regionforwardingrules: scope.Cloud().ForwardingRules(),
+		regionhealthchecks:    scope.Cloud().RegionHealthChecks(),
 	}
 }
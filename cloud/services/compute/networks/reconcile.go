@@ -18,8 +18,10 @@ package networks
 
 import (
 	"context"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
@@ -36,6 +38,10 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if err := s.createOrGetSubnets(ctx, network); err != nil {
+		return err
+	}
+
 	if network.Description == infrav1.ClusterTagKey(s.scope.Name()) {
 		router, err := s.createOrGetRouter(ctx, network)
 		if err != nil {
@@ -60,6 +66,15 @@ func (s *Service) Delete(ctx context.Context) error {
 		return gcperrors.IgnoreNotFound(err)
 	}
 
+	// Networks and subnetworks in a shared vpc host project are never owned by capg.
+	if s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	if err := s.deleteSubnets(ctx); err != nil {
+		return err
+	}
+
 	if network.Description != infrav1.ClusterTagKey(s.scope.Name()) {
 		return nil
 	}
@@ -102,6 +117,11 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 			return nil, err
 		}
 
+		if s.scope.IsSharedVpc() {
+			log.Error(err, "Shared vpc network not found in host project", "name", s.scope.NetworkName(), "project", s.scope.NetworkProject())
+			return nil, errors.Wrapf(err, "shared vpc network %s not found in host project %s", s.scope.NetworkName(), s.scope.NetworkProject())
+		}
+
 		log.V(2).Info("Creating a network", "name", s.scope.NetworkName())
 		if err := s.networks.Insert(ctx, networkKey, s.scope.NetworkSpec()); err != nil {
 			log.Error(err, "Error creating a network", "name", s.scope.NetworkName())
@@ -117,6 +137,90 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 	return network, nil
 }
 
+// createOrGetSubnets creates the subnetworks if not exist otherwise return the existing.
+func (s *Service) createOrGetSubnets(ctx context.Context, network *compute.Network) error {
+	log := log.FromContext(ctx)
+	subnetsMap := s.scope.Network().Subnets
+	if subnetsMap == nil {
+		subnetsMap = make(map[string]string)
+	}
+
+	for _, spec := range s.scope.SubnetSpecs() {
+		log.V(2).Info("Looking for subnetwork", "name", spec.Name, "region", spec.Region)
+		subnetKey := meta.RegionalKey(spec.Name, spec.Region)
+		subnet, err := s.subnetworks.Get(ctx, subnetKey)
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				log.Error(err, "Error looking for subnetwork", "name", spec.Name, "region", spec.Region)
+				return err
+			}
+
+			if s.scope.IsSharedVpc() {
+				log.Error(err, "Shared vpc subnetwork not found in host project", "name", spec.Name, "region", spec.Region, "project", s.scope.NetworkProject())
+				return errors.Wrapf(err, "shared vpc subnetwork %s not found in host project %s", spec.Name, s.scope.NetworkProject())
+			}
+
+			spec.Network = network.SelfLink
+			log.V(2).Info("Creating a subnetwork", "name", spec.Name, "region", spec.Region)
+			if err := s.subnetworks.Insert(ctx, subnetKey, spec); err != nil {
+				log.Error(err, "Error creating a subnetwork", "name", spec.Name, "region", spec.Region)
+				return err
+			}
+
+			subnet, err = s.subnetworks.Get(ctx, subnetKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		subnetsMap[spec.Name] = subnet.SelfLink
+	}
+
+	s.scope.Network().Subnets = subnetsMap
+	return nil
+}
+
+// deleteSubnets deletes the subnetworks created by capg.
+func (s *Service) deleteSubnets(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	for _, spec := range s.scope.SubnetSpecs() {
+		log.V(2).Info("Looking for subnetwork before deleting", "name", spec.Name, "region", spec.Region)
+		subnetKey := meta.RegionalKey(spec.Name, spec.Region)
+		subnet, err := s.subnetworks.Get(ctx, subnetKey)
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				log.Error(err, "Error looking for subnetwork before deleting", "name", spec.Name, "region", spec.Region)
+				return err
+			}
+
+			delete(s.scope.Network().Subnets, spec.Name)
+			continue
+		}
+
+		// Subnetworks whose description doesn't start with the cluster tag were not created by capg.
+		if !s.isOwnedSubnet(subnet) {
+			continue
+		}
+
+		log.V(2).Info("Deleting a subnetwork", "name", spec.Name, "region", spec.Region)
+		if err := s.subnetworks.Delete(ctx, subnetKey); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting a subnetwork", "name", spec.Name, "region", spec.Region)
+			return err
+		}
+
+		delete(s.scope.Network().Subnets, spec.Name)
+	}
+
+	return nil
+}
+
+// isOwnedSubnet returns true if the subnetwork was created by capg for the cluster, see the subnetwork specs of
+// the scope.
+func (s *Service) isOwnedSubnet(subnet *compute.Subnetwork) bool {
+	tag := infrav1.ClusterTagKey(s.scope.Name())
+	return subnet.Description == tag || strings.HasPrefix(subnet.Description, tag+": ")
+}
+
 // createOrGetRouter creates a cloudnat router if not exist otherwise return the existing.
 func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Network) (*compute.Router, error) {
 	log := log.FromContext(ctx)
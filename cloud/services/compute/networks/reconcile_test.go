@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name:                  pointer.String("my-network"),
+			AutoCreateSubnetworks: pointer.Bool(false),
+			Subnets: infrav1.Subnets{
+				{
+					Name:      "my-subnet",
+					CidrBlock: "10.0.0.0/20",
+					SecondaryCidrBlocks: map[string]string{
+						"services": "10.1.0.0/20",
+						"pods":     "10.2.0.0/16",
+					},
+					PrivateGoogleAccess: pointer.Bool(true),
+				},
+			},
+		},
+	},
+}
+
+func TestService_createOrGetSubnets(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+		GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+		Client:      fakec,
+		Cluster:     fakeCluster,
+		GCPCluster:  fakeGCPCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	network := &compute.Network{
+		Name:     "my-network",
+		SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/global/networks/my-network",
+	}
+
+	tests := []struct {
+		name           string
+		mockSubnetwork *cloud.MockSubnetworks
+		want           *compute.Subnetwork
+		wantErr        bool
+	}{
+		{
+			name: "subnetwork already exist (should return existing subnetwork)",
+			mockSubnetwork: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					{Name: "my-subnet", Region: "us-central1"}: {Obj: &compute.Subnetwork{
+						Name:     "my-subnet",
+						SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/subnetworks/my-subnet",
+					}},
+				},
+			},
+			want: &compute.Subnetwork{
+				Name:     "my-subnet",
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/subnetworks/my-subnet",
+			},
+		},
+		{
+			name: "error getting subnetwork with non 404 error code (should return an error)",
+			mockSubnetwork: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockSubnetworksObj{},
+				GetHook: func(ctx context.Context, key *meta.Key, m *cloud.MockSubnetworks) (bool, *compute.Subnetwork, error) {
+					return true, &compute.Subnetwork{}, &googleapi.Error{Code: http.StatusBadRequest}
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "subnetwork does not exist (should create subnetwork)",
+			mockSubnetwork: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockSubnetworksObj{},
+			},
+			want: &compute.Subnetwork{
+				Name:                  "my-subnet",
+				Description:           "capg-cluster-my-cluster",
+				Region:                "us-central1",
+				Network:               "https://www.googleapis.com/compute/v1/projects/proj-id/global/networks/my-network",
+				IpCidrRange:           "10.0.0.0/20",
+				PrivateIpGoogleAccess: true,
+				SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+					{
+						RangeName:   "pods",
+						IpCidrRange: "10.2.0.0/16",
+					},
+					{
+						RangeName:   "services",
+						IpCidrRange: "10.1.0.0/20",
+					},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/regions/us-central1/subnetworks/my-subnet",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(clusterScope)
+			s.subnetworks = tt.mockSubnetwork
+			clusterScope.Network().Subnets = nil
+			err := s.createOrGetSubnets(ctx, network)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.createOrGetSubnets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got, err := tt.mockSubnetwork.Get(ctx, meta.RegionalKey("my-subnet", "us-central1"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("Service.createOrGetSubnets() mismatch (-want +got):\n%s", d)
+			}
+
+			if d := cmp.Diff(map[string]string{"my-subnet": tt.want.SelfLink}, clusterScope.Network().Subnets); d != "" {
+				t.Errorf("Service.createOrGetSubnets() status mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name           string
+		hostProject    *string
+		description    *string
+		subnetwork     *compute.Subnetwork
+		wantSubnetwork bool
+	}{
+		{
+			name: "subnetwork created by capg (should delete subnetwork)",
+			subnetwork: &compute.Subnetwork{
+				Name:        "my-subnet",
+				Description: infrav1.ClusterTagKey("my-cluster"),
+			},
+			wantSubnetwork: false,
+		},
+		{
+			name:        "subnetwork with a description created by capg (should delete subnetwork)",
+			description: pointer.String("my description"),
+			subnetwork: &compute.Subnetwork{
+				Name:        "my-subnet",
+				Description: "capg-cluster-my-cluster: my description",
+			},
+			wantSubnetwork: false,
+		},
+		{
+			name:        "subnetwork with the description of the spec not created by capg (should keep subnetwork)",
+			description: pointer.String("my description"),
+			subnetwork: &compute.Subnetwork{
+				Name:        "my-subnet",
+				Description: "my description",
+			},
+			wantSubnetwork: true,
+		},
+		{
+			name:        "subnetwork in a shared vpc host project (should keep subnetwork)",
+			hostProject: pointer.String("my-host-proj"),
+			subnetwork: &compute.Subnetwork{
+				Name:        "my-subnet",
+				Description: infrav1.ClusterTagKey("my-cluster"),
+			},
+			wantSubnetwork: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			fakec := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				Build()
+
+			gcpCluster := fakeGCPCluster.DeepCopy()
+			gcpCluster.Spec.Network.HostProject = tt.hostProject
+			gcpCluster.Spec.Network.Subnets[0].Description = tt.description
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+				Client:      fakec,
+				Cluster:     fakeCluster,
+				GCPCluster:  gcpCluster,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(clusterScope)
+			s.networks = &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					{Name: "my-network"}: {Obj: &compute.Network{Name: "my-network"}},
+				},
+			}
+			subnetworks := &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					{Name: "my-subnet", Region: "us-central1"}: {Obj: tt.subnetwork},
+				},
+			}
+			s.subnetworks = subnetworks
+			if err := s.Delete(ctx); err != nil {
+				t.Fatalf("Service.Delete() error = %v", err)
+			}
+
+			_, err = subnetworks.Get(ctx, meta.RegionalKey("my-subnet", "us-central1"))
+			if got := err == nil; got != tt.wantSubnetwork {
+				t.Errorf("Service.Delete() subnetwork kept = %v, want %v", got, tt.wantSubnetwork)
+			}
+		})
+	}
+}
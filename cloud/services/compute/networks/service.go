@@ -36,18 +36,27 @@ type routersInterface interface {
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
+type subnetworksInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.Subnetwork, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Cluster
+	IsSharedVpc() bool
 	NetworkSpec() *compute.Network
+	SubnetSpecs() []*compute.Subnetwork
 	NatRouterSpec() *compute.Router
 }
 
 // Service implements networks reconciler.
 type Service struct {
-	scope    Scope
-	networks networksInterface
-	routers  routersInterface
+	scope       Scope
+	networks    networksInterface
+	subnetworks subnetworksInterface
+	routers     routersInterface
 }
 
 var _ cloud.Reconciler = &Service{}
@@ -55,8 +64,9 @@ var _ cloud.Reconciler = &Service{}
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
-		scope:    scope,
-		networks: scope.Cloud().Networks(),
-		routers:  scope.Cloud().Routers(),
+		scope:       scope,
+		networks:    scope.NetworkCloud().Networks(),
+		subnetworks: scope.NetworkCloud().Subnetworks(),
+		routers:     scope.NetworkCloud().Routers(),
 	}
 }
@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	"google.golang.org/api/container/v1"
+)
+
+// clustersClient implements clustersInterface. The GKE operations take several minutes, the clients do not
+// wait for them to complete: the progress is tracked with the status of the cluster.
+type clustersClient struct {
+	service *container.Service
+}
+
+func (c *clustersClient) Get(ctx context.Context, name string) (*container.Cluster, error) {
+	return c.service.Projects.Locations.Clusters.Get(name).Context(ctx).Do()
+}
+
+func (c *clustersClient) Create(ctx context.Context, parent string, cluster *container.Cluster) error {
+	_, err := c.service.Projects.Locations.Clusters.Create(parent, &container.CreateClusterRequest{Cluster: cluster}).Context(ctx).Do()
+	return err
+}
+
+func (c *clustersClient) Update(ctx context.Context, name string, update *container.ClusterUpdate) error {
+	_, err := c.service.Projects.Locations.Clusters.Update(name, &container.UpdateClusterRequest{Update: update}).Context(ctx).Do()
+	return err
+}
+
+func (c *clustersClient) Delete(ctx context.Context, name string) error {
+	_, err := c.service.Projects.Locations.Clusters.Delete(name).Context(ctx).Do()
+	return err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/container/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// kubeconfigTokenExpiryAnnotation records the expiry of the access token of the kubeconfig secret.
+	kubeconfigTokenExpiryAnnotation = "infrastructure.cluster.x-k8s.io/kubeconfig-token-expiry"
+
+	// kubeconfigRefreshWindow is how long before its expiry the access token of the kubeconfig is refreshed.
+	kubeconfigRefreshWindow = 15 * time.Minute
+)
+
+// reconcileKubeconfig writes the kubeconfig secret of the GKE cluster in the Cluster API format. GKE clusters
+// authenticate with access tokens, the token of the kubeconfig is refreshed before it expires.
+func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *container.Cluster) error {
+	log := log.FromContext(ctx)
+	clusterKey := client.ObjectKey{Namespace: s.scope.Namespace(), Name: s.scope.Name()}
+	existing := &corev1.Secret{}
+	err := s.scope.Client().Get(ctx, client.ObjectKey{Namespace: clusterKey.Namespace, Name: secret.Name(clusterKey.Name, secret.Kubeconfig)}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "failed to get kubeconfig secret")
+	}
+
+	found := err == nil
+	if found && !needsRefresh(existing) {
+		return nil
+	}
+
+	token, expiry, err := s.scope.KubeconfigToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := kubeconfigData(s.scope.Name(), cluster, token)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		log.V(2).Info("Creating kubeconfig secret", "name", secret.Name(clusterKey.Name, secret.Kubeconfig))
+		kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(clusterKey, data, s.scope.KubeconfigOwnerRef())
+		kubeconfigSecret.Annotations = map[string]string{kubeconfigTokenExpiryAnnotation: expiry.UTC().Format(time.RFC3339)}
+		if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
+			return errors.Wrap(err, "failed to create kubeconfig secret")
+		}
+
+		return nil
+	}
+
+	log.V(2).Info("Refreshing kubeconfig secret", "name", existing.Name)
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[kubeconfigTokenExpiryAnnotation] = expiry.UTC().Format(time.RFC3339)
+	existing.Data[secret.KubeconfigDataName] = data
+	if err := s.scope.Client().Update(ctx, existing); err != nil {
+		return errors.Wrap(err, "failed to update kubeconfig secret")
+	}
+
+	return nil
+}
+
+// needsRefresh returns true if the access token of the kubeconfig secret expires within the refresh window.
+func needsRefresh(kubeconfigSecret *corev1.Secret) bool {
+	expiry, err := time.Parse(time.RFC3339, kubeconfigSecret.Annotations[kubeconfigTokenExpiryAnnotation])
+	if err != nil {
+		return true
+	}
+
+	return time.Until(expiry) < kubeconfigRefreshWindow
+}
+
+// kubeconfigData returns the kubeconfig of the GKE cluster authenticated with the access token.
+func kubeconfigData(clusterName string, cluster *container.Cluster, token string) ([]byte, error) {
+	if cluster.MasterAuth == nil {
+		return nil, errors.New("GKE cluster has no certificate authority")
+	}
+
+	caData, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCaCertificate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode GKE cluster certificate authority")
+	}
+
+	userName := fmt.Sprintf("%s-admin", clusterName)
+	contextName := fmt.Sprintf("%s@%s", userName, clusterName)
+	cfg := &api.Config{
+		Clusters: map[string]*api.Cluster{
+			clusterName: {
+				Server:                   fmt.Sprintf("https://%s", cluster.Endpoint),
+				CertificateAuthorityData: caData,
+			},
+		},
+		Contexts: map[string]*api.Context{
+			contextName: {
+				Cluster:  clusterName,
+				AuthInfo: userName,
+			},
+		},
+		AuthInfos: map[string]*api.AuthInfo{
+			userName: {
+				Token: token,
+			},
+		},
+		CurrentContext: contextName,
+	}
+
+	data, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize kubeconfig")
+	}
+
+	return data, nil
+}
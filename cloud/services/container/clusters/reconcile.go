@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/container/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GKE cluster statuses.
+const (
+	clusterStatusProvisioning = "PROVISIONING"
+	clusterStatusRunning      = "RUNNING"
+	clusterStatusReconciling  = "RECONCILING"
+	clusterStatusStopping     = "STOPPING"
+	clusterStatusError        = "ERROR"
+	clusterStatusDegraded     = "DEGRADED"
+)
+
+// Reconcile reconcile the GKE cluster of the managed control plane.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GKE cluster")
+
+	cluster, err := s.createOrGetCluster(ctx)
+	if err != nil {
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, gcperrors.ReasonForError(err, infrav1.GKEControlPlaneReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	if cluster == nil {
+		log.Info("Waiting for a GCPManagedMachinePool to create the GKE cluster with", "name", s.scope.ClusterName())
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.WaitingForNodePoolsReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	switch cluster.Status {
+	case clusterStatusProvisioning:
+		log.Info("GKE cluster is provisioning", "name", cluster.Name)
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	case clusterStatusStopping:
+		log.Info("GKE cluster is stopping", "name", cluster.Name)
+		s.scope.SetNotReady()
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	case clusterStatusError, clusterStatusDegraded:
+		log.Info("GKE cluster is unhealthy", "name", cluster.Name, "status", cluster.Status, "message", cluster.StatusMessage)
+		s.scope.SetNotReady()
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneErrorReason, clusterv1.ConditionSeverityError,
+			fmt.Sprintf("GKE cluster is %s: %s", cluster.Status, cluster.StatusMessage))
+		return nil
+	}
+
+	// The API server of a reconciling cluster keeps serving requests, e.g. during a control plane upgrade.
+	s.scope.SetEndpoint(cluster.Endpoint)
+	if err := s.reconcileKubeconfig(ctx, cluster); err != nil {
+		log.Error(err, "Error reconciling kubeconfig", "name", cluster.Name)
+		s.scope.MarkConditionFalse(infrav1.KubeconfigReadyCondition, infrav1.KubeconfigReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	s.scope.MarkConditionTrue(infrav1.KubeconfigReadyCondition)
+	s.scope.SetInitialized()
+	s.scope.SetCurrentVersion(cluster.CurrentMasterVersion)
+	s.scope.SetReady()
+
+	if cluster.Status == clusterStatusReconciling {
+		log.Info("GKE cluster is updating", "name", cluster.Name)
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	// GKE runs a single operation at a time on a cluster, the updates are applied one per reconciliation.
+	if update := s.clusterUpdate(cluster); update != nil {
+		log.V(2).Info("Updating GKE cluster", "name", cluster.Name)
+		if err := s.clusters.Update(ctx, s.scope.ClusterFullName(), update); err != nil {
+			log.Error(err, "Error updating GKE cluster", "name", cluster.Name)
+			s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, gcperrors.ReasonForError(err, infrav1.GKEControlPlaneReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+			return err
+		}
+
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	s.scope.MarkConditionTrue(infrav1.GKEControlPlaneReadyCondition)
+	return nil
+}
+
+// Delete delete the GKE cluster of the managed control plane. The GKE cluster is deleted once the
+// GKEControlPlaneReady condition has the Deleted reason.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting GKE cluster")
+
+	cluster, err := s.clusters.Get(ctx, s.scope.ClusterFullName())
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+			return nil
+		}
+
+		log.Error(err, "Error looking for GKE cluster before deleting", "name", s.scope.ClusterName())
+		return err
+	}
+
+	s.scope.SetNotReady()
+	if cluster.Status == clusterStatusStopping {
+		log.Info("GKE cluster is stopping", "name", cluster.Name)
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	log.V(2).Info("Deleting GKE cluster", "name", cluster.Name)
+	if err := s.clusters.Delete(ctx, s.scope.ClusterFullName()); err != nil {
+		log.Error(err, "Error deleting GKE cluster", "name", cluster.Name)
+		s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	s.scope.MarkConditionFalse(infrav1.GKEControlPlaneReadyCondition, infrav1.GKEControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
+
+// createOrGetCluster returns the GKE cluster, creating it if it does not exist. It returns nil when a standard
+// cluster has no node pool to be created with.
+func (s *Service) createOrGetCluster(ctx context.Context) (*container.Cluster, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for GKE cluster", "name", s.scope.ClusterName())
+	cluster, err := s.clusters.Get(ctx, s.scope.ClusterFullName())
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for GKE cluster", "name", s.scope.ClusterName())
+			return nil, err
+		}
+
+		spec := s.scope.ClusterSpec()
+		nodePools, err := s.scope.NodePoolSpecs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if spec.Autopilot == nil && len(nodePools) == 0 {
+			return nil, nil
+		}
+
+		spec.NodePools = nodePools
+		log.V(2).Info("Creating a GKE cluster", "name", s.scope.ClusterName())
+		if err := s.clusters.Create(ctx, s.scope.ClusterLocation(), spec); err != nil {
+			log.Error(err, "Error creating a GKE cluster", "name", s.scope.ClusterName())
+			return nil, err
+		}
+
+		cluster, err = s.clusters.Get(ctx, s.scope.ClusterFullName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cluster, nil
+}
+
+// clusterUpdate returns the next update to apply to the GKE cluster, or nil when the cluster is up to date.
+func (s *Service) clusterUpdate(cluster *container.Cluster) *container.ClusterUpdate {
+	if version := s.scope.DesiredVersion(); version != nil && !versionMatches(cluster.CurrentMasterVersion, *version) {
+		return &container.ClusterUpdate{
+			DesiredMasterVersion: *version,
+		}
+	}
+
+	if channel := s.scope.DesiredReleaseChannel(); channel != "" && (cluster.ReleaseChannel == nil || cluster.ReleaseChannel.Channel != channel) {
+		return &container.ClusterUpdate{
+			DesiredReleaseChannel: &container.ReleaseChannel{Channel: channel},
+		}
+	}
+
+	return nil
+}
+
+// versionMatches returns true if the GKE version, e.g. 1.24.5-gke.600, matches the requested version.
+// A version without GKE patch, e.g. 1.24 or 1.24.5, matches any GKE version of the same minor or patch.
+func versionMatches(current, desired string) bool {
+	if strings.Contains(desired, "-") {
+		return current == desired
+	}
+
+	currentParts := strings.Split(strings.SplitN(current, "-", 2)[0], ".")
+	desiredParts := strings.Split(desired, ".")
+	if len(desiredParts) > len(currentParts) {
+		return false
+	}
+
+	for i := range desiredParts {
+		if currentParts[i] != desiredParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
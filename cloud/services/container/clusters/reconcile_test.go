@@ -0,0 +1,351 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = expclusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+const fakeClusterFullName = "projects/my-proj/locations/us-central1/clusters/my-cluster"
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPManagedCluster = &infrav1.GCPManagedCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPManagedClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+var fakeGCPManagedControlPlane = &infrav1.GCPManagedControlPlane{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster-control-plane",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPManagedControlPlaneSpec{
+		ControlPlaneVersion: pointer.String("v1.24"),
+	},
+}
+
+var fakeMachinePool = &expclusterv1.MachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+		Labels: map[string]string{
+			clusterv1.ClusterLabelName: "my-cluster",
+		},
+	},
+	Spec: expclusterv1.MachinePoolSpec{
+		ClusterName: "my-cluster",
+		Replicas:    pointer.Int32(3),
+	},
+}
+
+var fakeGCPManagedMachinePool = &infrav1.GCPManagedMachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+		Labels: map[string]string{
+			clusterv1.ClusterLabelName: "my-cluster",
+		},
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion: expclusterv1.GroupVersion.String(),
+				Kind:       "MachinePool",
+				Name:       "my-pool",
+			},
+		},
+	},
+	Spec: infrav1.GCPManagedMachinePoolSpec{
+		MachineType: pointer.String("e2-medium"),
+	},
+}
+
+// fakeKubeconfigSecret holds a kubeconfig whose access token does not need to be refreshed.
+var fakeKubeconfigSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      secret.Name("my-cluster", secret.Kubeconfig),
+		Namespace: "default",
+		Annotations: map[string]string{
+			kubeconfigTokenExpiryAnnotation: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		},
+	},
+	Data: map[string][]byte{
+		secret.KubeconfigDataName: []byte("kubeconfig"),
+	},
+}
+
+// fakeClusters is an in-memory clustersInterface.
+type fakeClusters struct {
+	clusters map[string]*container.Cluster
+	updates  []*container.ClusterUpdate
+}
+
+func (f *fakeClusters) Get(_ context.Context, name string) (*container.Cluster, error) {
+	cluster, ok := f.clusters[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return cluster, nil
+}
+
+func (f *fakeClusters) Create(_ context.Context, parent string, cluster *container.Cluster) error {
+	cluster.Status = clusterStatusProvisioning
+	f.clusters[parent+"/clusters/"+cluster.Name] = cluster
+	return nil
+}
+
+func (f *fakeClusters) Update(_ context.Context, name string, update *container.ClusterUpdate) error {
+	cluster, ok := f.clusters[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	cluster.Status = clusterStatusReconciling
+	f.updates = append(f.updates, update)
+	return nil
+}
+
+func (f *fakeClusters) Delete(_ context.Context, name string) error {
+	cluster, ok := f.clusters[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	cluster.Status = clusterStatusStopping
+	return nil
+}
+
+func newManagedControlPlaneScope(t *testing.T, objs ...client.Object) *scope.ManagedControlPlaneScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(append(objs, fakeKubeconfigSecret.DeepCopy())...).
+		Build()
+
+	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		GCPServices:            scope.GCPServices{Container: &container.Service{}},
+		Client:                 fakec,
+		Cluster:                fakeCluster,
+		GCPManagedCluster:      fakeGCPManagedCluster.DeepCopy(),
+		GCPManagedControlPlane: fakeGCPManagedControlPlane.DeepCopy(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return managedControlPlaneScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		clusters    map[string]*container.Cluster
+		objs        []client.Object
+		wantUpdate  *container.ClusterUpdate
+		wantReason  string
+		wantReady   bool
+		wantCreated bool
+	}{
+		{
+			name:       "GKE cluster does not exist without node pools (should wait)",
+			clusters:   map[string]*container.Cluster{},
+			wantReason: infrav1.WaitingForNodePoolsReason,
+			wantReady:  false,
+		},
+		{
+			name:        "GKE cluster does not exist (should create it)",
+			clusters:    map[string]*container.Cluster{},
+			objs:        []client.Object{fakeMachinePool.DeepCopy(), fakeGCPManagedMachinePool.DeepCopy()},
+			wantReason:  infrav1.GKEControlPlaneCreatingReason,
+			wantReady:   false,
+			wantCreated: true,
+		},
+		{
+			name: "GKE cluster runs an older version (should upgrade it)",
+			clusters: map[string]*container.Cluster{
+				fakeClusterFullName: {
+					Name:                 "my-cluster",
+					Status:               clusterStatusRunning,
+					Endpoint:             "10.0.0.1",
+					CurrentMasterVersion: "1.23.12-gke.100",
+				},
+			},
+			wantUpdate: &container.ClusterUpdate{DesiredMasterVersion: "1.24"},
+			wantReason: infrav1.GKEControlPlaneUpdatingReason,
+			wantReady:  true,
+		},
+		{
+			name: "GKE cluster up to date (should be ready)",
+			clusters: map[string]*container.Cluster{
+				fakeClusterFullName: {
+					Name:                 "my-cluster",
+					Status:               clusterStatusRunning,
+					Endpoint:             "10.0.0.1",
+					CurrentMasterVersion: "1.24.5-gke.600",
+				},
+			},
+			wantReady: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			managedControlPlaneScope := newManagedControlPlaneScope(t, tt.objs...)
+			clusters := &fakeClusters{clusters: tt.clusters}
+			s := &Service{
+				scope:    managedControlPlaneScope,
+				clusters: clusters,
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			gcpManagedControlPlane := managedControlPlaneScope.GCPManagedControlPlane
+			if tt.wantCreated {
+				cluster, ok := clusters.clusters[fakeClusterFullName]
+				if !ok {
+					t.Fatalf("Service.Reconcile() did not create GKE cluster %s", fakeClusterFullName)
+				}
+				if cluster.InitialClusterVersion != "1.24" {
+					t.Errorf("Service.Reconcile() InitialClusterVersion = %s, want 1.24", cluster.InitialClusterVersion)
+				}
+				if len(cluster.NodePools) != 1 || cluster.NodePools[0].Name != "my-pool" || cluster.NodePools[0].InitialNodeCount != 1 {
+					t.Errorf("Service.Reconcile() NodePools = %v, want my-pool with 1 node per zone", cluster.NodePools)
+				}
+			}
+
+			switch {
+			case tt.wantUpdate == nil && len(clusters.updates) > 0:
+				t.Errorf("Service.Reconcile() updates = %v, want none", clusters.updates)
+			case tt.wantUpdate != nil && (len(clusters.updates) != 1 || clusters.updates[0].DesiredMasterVersion != tt.wantUpdate.DesiredMasterVersion):
+				t.Errorf("Service.Reconcile() updates = %v, want %v", clusters.updates, tt.wantUpdate)
+			}
+
+			if gcpManagedControlPlane.Status.Ready != tt.wantReady {
+				t.Errorf("Service.Reconcile() Ready = %v, want %v", gcpManagedControlPlane.Status.Ready, tt.wantReady)
+			}
+			if tt.wantReady && gcpManagedControlPlane.Spec.ControlPlaneEndpoint.Host != "10.0.0.1" {
+				t.Errorf("Service.Reconcile() ControlPlaneEndpoint = %v, want 10.0.0.1", gcpManagedControlPlane.Spec.ControlPlaneEndpoint)
+			}
+
+			if tt.wantReason == "" {
+				if !conditions.IsTrue(gcpManagedControlPlane, infrav1.GKEControlPlaneReadyCondition) {
+					t.Errorf("Service.Reconcile() GKEControlPlaneReady is not true")
+				}
+			} else if got := conditions.GetReason(gcpManagedControlPlane, infrav1.GKEControlPlaneReadyCondition); got != tt.wantReason {
+				t.Errorf("Service.Reconcile() GKEControlPlaneReady reason = %s, want %s", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		clusters   map[string]*container.Cluster
+		wantReason string
+	}{
+		{
+			name: "GKE cluster running (should delete it)",
+			clusters: map[string]*container.Cluster{
+				fakeClusterFullName: {Name: "my-cluster", Status: clusterStatusRunning},
+			},
+			wantReason: infrav1.GKEControlPlaneDeletingReason,
+		},
+		{
+			name:       "GKE cluster does not exist (should be deleted)",
+			clusters:   map[string]*container.Cluster{},
+			wantReason: clusterv1.DeletedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			managedControlPlaneScope := newManagedControlPlaneScope(t)
+			s := &Service{
+				scope:    managedControlPlaneScope,
+				clusters: &fakeClusters{clusters: tt.clusters},
+			}
+
+			if err := s.Delete(ctx); err != nil {
+				t.Fatalf("Service.Delete() error = %v", err)
+			}
+
+			if got := conditions.GetReason(managedControlPlaneScope.GCPManagedControlPlane, infrav1.GKEControlPlaneReadyCondition); got != tt.wantReason {
+				t.Errorf("Service.Delete() GKEControlPlaneReady reason = %s, want %s", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestVersionMatches(t *testing.T) {
+	tests := []struct {
+		current string
+		desired string
+		want    bool
+	}{
+		{current: "1.24.5-gke.600", desired: "1.24", want: true},
+		{current: "1.24.5-gke.600", desired: "1.24.5", want: true},
+		{current: "1.24.5-gke.600", desired: "1.24.5-gke.600", want: true},
+		{current: "1.24.5-gke.600", desired: "1.24.5-gke.700", want: false},
+		{current: "1.24.5-gke.600", desired: "1.25", want: false},
+		{current: "1.24.5-gke.600", desired: "1.2", want: false},
+	}
+	for _, tt := range tests {
+		if got := versionMatches(tt.current, tt.desired); got != tt.want {
+			t.Errorf("versionMatches(%q, %q) = %v, want %v", tt.current, tt.desired, got, tt.want)
+		}
+	}
+}
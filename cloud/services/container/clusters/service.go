@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+
+	"google.golang.org/api/container/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type clustersInterface interface {
+	Get(ctx context.Context, name string) (*container.Cluster, error)
+	Create(ctx context.Context, parent string, cluster *container.Cluster) error
+	Update(ctx context.Context, name string, update *container.ClusterUpdate) error
+	Delete(ctx context.Context, name string) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ManagedControlPlane
+}
+
+// Service implements GKE clusters reconciler.
+type Service struct {
+	scope    Scope
+	clusters clustersInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope: scope,
+		clusters: &clustersClient{
+			service: scope.ContainerService(),
+		},
+	}
+}
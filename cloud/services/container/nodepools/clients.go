@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+)
+
+// nodepoolsClient implements nodepoolsInterface. The GKE operations take several minutes, the clients do not
+// wait for them to complete: the progress is tracked with the status of the node pool.
+type nodepoolsClient struct {
+	service *container.Service
+}
+
+func (c *nodepoolsClient) Get(ctx context.Context, name string) (*container.NodePool, error) {
+	return c.service.Projects.Locations.Clusters.NodePools.Get(name).Context(ctx).Do()
+}
+
+func (c *nodepoolsClient) Create(ctx context.Context, parent string, nodePool *container.NodePool) error {
+	_, err := c.service.Projects.Locations.Clusters.NodePools.Create(parent, &container.CreateNodePoolRequest{NodePool: nodePool}).Context(ctx).Do()
+	return err
+}
+
+func (c *nodepoolsClient) Update(ctx context.Context, name string, request *container.UpdateNodePoolRequest) error {
+	_, err := c.service.Projects.Locations.Clusters.NodePools.Update(name, request).Context(ctx).Do()
+	return err
+}
+
+func (c *nodepoolsClient) SetAutoscaling(ctx context.Context, name string, autoscaling *container.NodePoolAutoscaling) error {
+	_, err := c.service.Projects.Locations.Clusters.NodePools.SetAutoscaling(name, &container.SetNodePoolAutoscalingRequest{Autoscaling: autoscaling}).Context(ctx).Do()
+	return err
+}
+
+func (c *nodepoolsClient) SetSize(ctx context.Context, name string, nodeCount int64) error {
+	request := &container.SetNodePoolSizeRequest{NodeCount: nodeCount, ForceSendFields: []string{"NodeCount"}}
+	_, err := c.service.Projects.Locations.Clusters.NodePools.SetSize(name, request).Context(ctx).Do()
+	return err
+}
+
+func (c *nodepoolsClient) Delete(ctx context.Context, name string) error {
+	_, err := c.service.Projects.Locations.Clusters.NodePools.Delete(name).Context(ctx).Do()
+	return err
+}
+
+// instancegroupmanagersClient implements instancegroupmanagersInterface for the zonal managed instance
+// groups backing the node pools.
+type instancegroupmanagersClient struct {
+	service *compute.Service
+}
+
+// ListManagedInstances returns the instances of the managed instance group referenced by its URL,
+// e.g. https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroupManagers/my-group.
+func (c *instancegroupmanagersClient) ListManagedInstances(ctx context.Context, instanceGroupURL string) ([]*compute.ManagedInstance, error) {
+	parts := strings.Split(instanceGroupURL, "/")
+	if len(parts) < 6 || parts[len(parts)-6] != "projects" || parts[len(parts)-4] != "zones" {
+		return nil, errors.Errorf("invalid instance group URL %q", instanceGroupURL)
+	}
+
+	project, zone, name := parts[len(parts)-5], parts[len(parts)-3], parts[len(parts)-1]
+	var instances []*compute.ManagedInstance
+	err := c.service.InstanceGroupManagers.ListManagedInstances(project, zone, name).
+		Pages(ctx, func(list *compute.InstanceGroupManagersListManagedInstancesResponse) error {
+			instances = append(instances, list.ManagedInstances...)
+			return nil
+		})
+
+	return instances, err
+}
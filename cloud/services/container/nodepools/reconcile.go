@@ -0,0 +1,339 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/container/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GKE node pool statuses.
+const (
+	nodePoolStatusProvisioning     = "PROVISIONING"
+	nodePoolStatusRunning          = "RUNNING"
+	nodePoolStatusRunningWithError = "RUNNING_WITH_ERROR"
+	nodePoolStatusReconciling      = "RECONCILING"
+	nodePoolStatusStopping         = "STOPPING"
+	nodePoolStatusError            = "ERROR"
+)
+
+// Reconcile reconcile the GKE node pool of the managed machine pool.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GKE node pool")
+
+	nodePool, err := s.createOrGetNodePool(ctx)
+	if err != nil {
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, gcperrors.ReasonForError(err, infrav1.GKENodePoolReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	switch nodePool.Status {
+	case nodePoolStatusProvisioning:
+		log.Info("GKE node pool is provisioning", "name", nodePool.Name)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	case nodePoolStatusStopping:
+		log.Info("GKE node pool is stopping", "name", nodePool.Name)
+		s.scope.SetNotReady()
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolDeletingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	case nodePoolStatusError:
+		log.Info("GKE node pool is unhealthy", "name", nodePool.Name, "message", nodePool.StatusMessage)
+		s.scope.SetNotReady()
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolErrorReason, clusterv1.ConditionSeverityError,
+			fmt.Sprintf("GKE node pool is %s: %s", nodePool.Status, nodePool.StatusMessage))
+		return nil
+	}
+
+	// The nodes of a running or reconciling node pool are registered with the MachinePool.
+	nodes, err := s.reconcileProviderIDs(ctx, nodePool)
+	if err != nil {
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, gcperrors.ReasonForError(err, infrav1.GKENodePoolReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	s.scope.SetReady()
+
+	switch nodePool.Status {
+	case nodePoolStatusReconciling:
+		log.Info("GKE node pool is updating", "name", nodePool.Name)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	case nodePoolStatusRunningWithError:
+		log.Info("GKE node pool is running with errors", "name", nodePool.Name, "message", nodePool.StatusMessage)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolErrorReason, clusterv1.ConditionSeverityWarning,
+			fmt.Sprintf("GKE node pool is %s: %s", nodePool.Status, nodePool.StatusMessage))
+		return nil
+	}
+
+	// GKE runs a single operation at a time on a node pool, the updates are applied one per reconciliation.
+	updated, err := s.updateNodePool(ctx, nodePool, nodes)
+	if err != nil {
+		log.Error(err, "Error updating GKE node pool", "name", nodePool.Name)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, gcperrors.ReasonForError(err, infrav1.GKENodePoolReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
+		return err
+	}
+
+	if updated {
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	s.scope.MarkConditionTrue(infrav1.GKENodePoolReadyCondition)
+	return nil
+}
+
+// Delete delete the GKE node pool of the managed machine pool. The GKE node pool is deleted once the
+// GKENodePoolReady condition has the Deleted reason.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting GKE node pool")
+
+	nodePool, err := s.nodepools.Get(ctx, s.scope.NodePoolFullName())
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+			return nil
+		}
+
+		log.Error(err, "Error looking for GKE node pool before deleting", "name", s.scope.NodePoolName())
+		return err
+	}
+
+	s.scope.SetNotReady()
+	if nodePool.Status == nodePoolStatusStopping {
+		log.Info("GKE node pool is stopping", "name", nodePool.Name)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolDeletingReason, clusterv1.ConditionSeverityInfo, "")
+		return nil
+	}
+
+	log.V(2).Info("Deleting GKE node pool", "name", nodePool.Name)
+	if err := s.nodepools.Delete(ctx, s.scope.NodePoolFullName()); err != nil {
+		log.Error(err, "Error deleting GKE node pool", "name", nodePool.Name)
+		s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	s.scope.MarkConditionFalse(infrav1.GKENodePoolReadyCondition, infrav1.GKENodePoolDeletingReason, clusterv1.ConditionSeverityInfo, "")
+	return nil
+}
+
+func (s *Service) createOrGetNodePool(ctx context.Context) (*container.NodePool, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for GKE node pool", "name", s.scope.NodePoolName())
+	nodePool, err := s.nodepools.Get(ctx, s.scope.NodePoolFullName())
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for GKE node pool", "name", s.scope.NodePoolName())
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a GKE node pool", "name", s.scope.NodePoolName())
+		if err := s.nodepools.Create(ctx, s.scope.ClusterFullName(), s.scope.NodePoolSpec(0)); err != nil {
+			log.Error(err, "Error creating a GKE node pool", "name", s.scope.NodePoolName())
+			return nil, err
+		}
+
+		nodePool, err = s.nodepools.Get(ctx, s.scope.NodePoolFullName())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return nodePool, nil
+}
+
+// reconcileProviderIDs sets the provider IDs of the instances of the node pool and returns the number of nodes.
+func (s *Service) reconcileProviderIDs(ctx context.Context, nodePool *container.NodePool) (int, error) {
+	log := log.FromContext(ctx)
+	providerIDs := []string{}
+	running := int32(0)
+	for _, instanceGroupURL := range nodePool.InstanceGroupUrls {
+		managedInstances, err := s.instancegroupmanagers.ListManagedInstances(ctx, instanceGroupURL)
+		if err != nil {
+			log.Error(err, "Error listing managed instances", "instanceGroup", instanceGroupURL)
+			return 0, err
+		}
+
+		for _, managedInstance := range managedInstances {
+			providerID, ok := providerIDFromInstanceURL(managedInstance.Instance)
+			if !ok {
+				continue
+			}
+
+			providerIDs = append(providerIDs, providerID)
+			if managedInstance.InstanceStatus == string(infrav1.InstanceStatusRunning) {
+				running++
+			}
+		}
+	}
+	sort.Strings(providerIDs)
+
+	s.scope.SetProviderIDList(providerIDs)
+	s.scope.SetReplicas(running)
+	return len(providerIDs), nil
+}
+
+// updateNodePool applies the next update of the GKE node pool, if any, and returns whether the node pool was updated.
+func (s *Service) updateNodePool(ctx context.Context, nodePool *container.NodePool, nodes int) (bool, error) {
+	log := log.FromContext(ctx)
+	name := s.scope.NodePoolFullName()
+	imageType := ""
+	if nodePool.Config != nil {
+		imageType = nodePool.Config.ImageType
+	}
+
+	if version := s.scope.DesiredVersion(); version != nil && !versionMatches(nodePool.Version, *version) {
+		log.V(2).Info("Upgrading GKE node pool", "name", nodePool.Name, "version", *version)
+		return true, s.nodepools.Update(ctx, name, &container.UpdateNodePoolRequest{
+			NodeVersion: *version,
+			ImageType:   imageType,
+		})
+	}
+
+	if !s.nodeConfigMatches(nodePool) {
+		log.V(2).Info("Updating GKE node pool labels, taints and upgrade settings", "name", nodePool.Name)
+		return true, s.nodepools.Update(ctx, name, &container.UpdateNodePoolRequest{
+			NodeVersion:     nodePool.Version,
+			ImageType:       imageType,
+			Labels:          &container.NodeLabels{Labels: s.scope.NodePoolLabels(), ForceSendFields: []string{"Labels"}},
+			Taints:          &container.NodeTaints{Taints: s.scope.NodePoolTaints(), ForceSendFields: []string{"Taints"}},
+			UpgradeSettings: s.scope.NodePoolUpgradeSettings(),
+		})
+	}
+
+	autoscaling := s.scope.NodePoolAutoscaling()
+	if !autoscalingMatches(nodePool.Autoscaling, autoscaling) {
+		log.V(2).Info("Updating GKE node pool autoscaling", "name", nodePool.Name)
+		return true, s.nodepools.SetAutoscaling(ctx, name, autoscaling)
+	}
+
+	// The size of an autoscaled node pool is managed by the cluster autoscaler.
+	if !autoscaling.Enabled && len(nodePool.Locations) > 0 {
+		nodeCount := s.scope.NodeCountPerZone(len(nodePool.Locations))
+		if int64(nodes) != nodeCount*int64(len(nodePool.Locations)) {
+			log.V(2).Info("Resizing GKE node pool", "name", nodePool.Name, "nodeCountPerZone", nodeCount)
+			return true, s.nodepools.SetSize(ctx, name, nodeCount)
+		}
+	}
+
+	return false, nil
+}
+
+// nodeConfigMatches returns true if the Kubernetes labels, taints and upgrade settings of the node pool
+// match the managed machine pool.
+func (s *Service) nodeConfigMatches(nodePool *container.NodePool) bool {
+	config := nodePool.Config
+	if config == nil {
+		config = &container.NodeConfig{}
+	}
+
+	labels := s.scope.NodePoolLabels()
+	if (len(labels) > 0 || len(config.Labels) > 0) && !reflect.DeepEqual(labels, config.Labels) {
+		return false
+	}
+
+	if !taintsMatch(config.Taints, s.scope.NodePoolTaints()) {
+		return false
+	}
+
+	if settings := s.scope.NodePoolUpgradeSettings(); settings != nil {
+		current := nodePool.UpgradeSettings
+		if current == nil || current.MaxSurge != settings.MaxSurge || current.MaxUnavailable != settings.MaxUnavailable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// taintsMatch returns true if both lists hold the same taints, in any order.
+func taintsMatch(current, desired []*container.NodeTaint) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+
+	taints := make(map[string]bool, len(current))
+	for _, taint := range current {
+		taints[taintKey(taint)] = true
+	}
+
+	for _, taint := range desired {
+		if !taints[taintKey(taint)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// taintKey returns the Kubernetes representation of a taint, e.g. key=value:NO_SCHEDULE.
+func taintKey(taint *container.NodeTaint) string {
+	return fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect)
+}
+
+// autoscalingMatches returns true if the autoscaling settings of the node pool match the desired settings.
+func autoscalingMatches(current, desired *container.NodePoolAutoscaling) bool {
+	if current == nil || !current.Enabled {
+		return !desired.Enabled
+	}
+
+	return desired.Enabled && current.MinNodeCount == desired.MinNodeCount && current.MaxNodeCount == desired.MaxNodeCount
+}
+
+// versionMatches returns true if the GKE version, e.g. 1.24.5-gke.600, matches the requested version.
+// A version without GKE patch, e.g. 1.24 or 1.24.5, matches any GKE version of the same minor or patch.
+func versionMatches(current, desired string) bool {
+	if strings.Contains(desired, "-") {
+		return current == desired
+	}
+
+	currentParts := strings.Split(strings.SplitN(current, "-", 2)[0], ".")
+	desiredParts := strings.Split(desired, ".")
+	if len(desiredParts) > len(currentParts) {
+		return false
+	}
+
+	for i := range desiredParts {
+		if currentParts[i] != desiredParts[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// providerIDFromInstanceURL returns the provider ID of the instance referenced by its URL,
+// e.g. https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-instance.
+func providerIDFromInstanceURL(instanceURL string) (string, bool) {
+	parts := strings.Split(instanceURL, "/")
+	if len(parts) < 6 || parts[len(parts)-6] != "projects" || parts[len(parts)-4] != "zones" || parts[len(parts)-2] != "instances" {
+		return "", false
+	}
+
+	return fmt.Sprintf("gce://%s/%s/%s", parts[len(parts)-5], parts[len(parts)-3], parts[len(parts)-1]), true
+}
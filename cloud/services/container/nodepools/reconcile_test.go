@@ -0,0 +1,403 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = expclusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+const (
+	fakeNodePoolFullName = "projects/my-proj/locations/us-central1/clusters/my-cluster/nodePools/my-pool"
+	fakeInstanceGroupURL = "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instanceGroupManagers/gke-my-pool-grp"
+)
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPManagedCluster = &infrav1.GCPManagedCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPManagedClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+var fakeGCPManagedControlPlane = &infrav1.GCPManagedControlPlane{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster-control-plane",
+		Namespace: "default",
+	},
+}
+
+var fakeMachinePool = &expclusterv1.MachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: expclusterv1.MachinePoolSpec{
+		ClusterName: "my-cluster",
+		Replicas:    pointer.Int32(3),
+		Template: clusterv1.MachineTemplateSpec{
+			Spec: clusterv1.MachineSpec{
+				Version: pointer.String("v1.24.5"),
+			},
+		},
+	},
+}
+
+var fakeGCPManagedMachinePool = &infrav1.GCPManagedMachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPManagedMachinePoolSpec{
+		MachineType: pointer.String("e2-medium"),
+	},
+}
+
+// fakeNodePools is an in-memory nodepoolsInterface.
+type fakeNodePools struct {
+	nodePools   map[string]*container.NodePool
+	updates     []*container.UpdateNodePoolRequest
+	autoscaling []*container.NodePoolAutoscaling
+	sizes       []int64
+}
+
+func (f *fakeNodePools) Get(_ context.Context, name string) (*container.NodePool, error) {
+	nodePool, ok := f.nodePools[name]
+	if !ok {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	return nodePool, nil
+}
+
+func (f *fakeNodePools) Create(_ context.Context, parent string, nodePool *container.NodePool) error {
+	nodePool.Status = nodePoolStatusProvisioning
+	f.nodePools[parent+"/nodePools/"+nodePool.Name] = nodePool
+	return nil
+}
+
+func (f *fakeNodePools) Update(_ context.Context, name string, request *container.UpdateNodePoolRequest) error {
+	nodePool, ok := f.nodePools[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	nodePool.Status = nodePoolStatusReconciling
+	f.updates = append(f.updates, request)
+	return nil
+}
+
+func (f *fakeNodePools) SetAutoscaling(_ context.Context, name string, autoscaling *container.NodePoolAutoscaling) error {
+	nodePool, ok := f.nodePools[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	nodePool.Status = nodePoolStatusReconciling
+	f.autoscaling = append(f.autoscaling, autoscaling)
+	return nil
+}
+
+func (f *fakeNodePools) SetSize(_ context.Context, name string, nodeCount int64) error {
+	nodePool, ok := f.nodePools[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	nodePool.Status = nodePoolStatusReconciling
+	f.sizes = append(f.sizes, nodeCount)
+	return nil
+}
+
+func (f *fakeNodePools) Delete(_ context.Context, name string) error {
+	nodePool, ok := f.nodePools[name]
+	if !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	nodePool.Status = nodePoolStatusStopping
+	return nil
+}
+
+// fakeInstanceGroupManagers is an in-memory instancegroupmanagersInterface.
+type fakeInstanceGroupManagers struct {
+	managedInstances map[string][]*compute.ManagedInstance
+}
+
+func (f *fakeInstanceGroupManagers) ListManagedInstances(_ context.Context, instanceGroupURL string) ([]*compute.ManagedInstance, error) {
+	return f.managedInstances[instanceGroupURL], nil
+}
+
+func newManagedMachinePoolScope(t *testing.T) *scope.ManagedMachinePoolScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	managedMachinePoolScope, err := scope.NewManagedMachinePoolScope(scope.ManagedMachinePoolScopeParams{
+		GCPServices:            scope.GCPServices{Compute: &compute.Service{}, Container: &container.Service{}},
+		Client:                 fakec,
+		Cluster:                fakeCluster,
+		MachinePool:            fakeMachinePool,
+		GCPManagedCluster:      fakeGCPManagedCluster,
+		GCPManagedControlPlane: fakeGCPManagedControlPlane,
+		GCPManagedMachinePool:  fakeGCPManagedMachinePool.DeepCopy(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return managedMachinePoolScope
+}
+
+// fakeRunningInstances returns the running managed instances of the node pool in a single zone.
+func fakeRunningInstances(names ...string) map[string][]*compute.ManagedInstance {
+	managedInstances := make([]*compute.ManagedInstance, 0, len(names))
+	for _, name := range names {
+		managedInstances = append(managedInstances, &compute.ManagedInstance{
+			Instance:       "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/" + name,
+			InstanceStatus: string(infrav1.InstanceStatusRunning),
+		})
+	}
+
+	return map[string][]*compute.ManagedInstance{fakeInstanceGroupURL: managedInstances}
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []struct {
+		name             string
+		nodePools        map[string]*container.NodePool
+		managedInstances map[string][]*compute.ManagedInstance
+		wantUpdate       bool
+		wantSize         *int64
+		wantReason       string
+		wantReady        bool
+		wantReplicas     int32
+		wantCreated      bool
+	}{
+		{
+			name:        "GKE node pool does not exist (should create it)",
+			nodePools:   map[string]*container.NodePool{},
+			wantReason:  infrav1.GKENodePoolCreatingReason,
+			wantReady:   false,
+			wantCreated: true,
+		},
+		{
+			name: "GKE node pool runs an older version (should upgrade it)",
+			nodePools: map[string]*container.NodePool{
+				fakeNodePoolFullName: {
+					Name:              "my-pool",
+					Status:            nodePoolStatusRunning,
+					Version:           "1.23.12-gke.100",
+					Locations:         []string{"us-central1-a"},
+					InstanceGroupUrls: []string{fakeInstanceGroupURL},
+				},
+			},
+			managedInstances: fakeRunningInstances("node-1", "node-2", "node-3"),
+			wantUpdate:       true,
+			wantReason:       infrav1.GKENodePoolUpdatingReason,
+			wantReady:        true,
+			wantReplicas:     3,
+		},
+		{
+			name: "GKE node pool has fewer nodes than the replicas (should resize it)",
+			nodePools: map[string]*container.NodePool{
+				fakeNodePoolFullName: {
+					Name:              "my-pool",
+					Status:            nodePoolStatusRunning,
+					Version:           "1.24.5-gke.600",
+					Locations:         []string{"us-central1-a"},
+					InstanceGroupUrls: []string{fakeInstanceGroupURL},
+				},
+			},
+			managedInstances: fakeRunningInstances("node-1"),
+			wantSize:         pointer.Int64(3),
+			wantReason:       infrav1.GKENodePoolUpdatingReason,
+			wantReady:        true,
+			wantReplicas:     1,
+		},
+		{
+			name: "GKE node pool up to date (should be ready)",
+			nodePools: map[string]*container.NodePool{
+				fakeNodePoolFullName: {
+					Name:              "my-pool",
+					Status:            nodePoolStatusRunning,
+					Version:           "1.24.5-gke.600",
+					Locations:         []string{"us-central1-a"},
+					InstanceGroupUrls: []string{fakeInstanceGroupURL},
+				},
+			},
+			managedInstances: fakeRunningInstances("node-1", "node-2", "node-3"),
+			wantReady:        true,
+			wantReplicas:     3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			managedMachinePoolScope := newManagedMachinePoolScope(t)
+			nodePools := &fakeNodePools{nodePools: tt.nodePools}
+			s := &Service{
+				scope:                 managedMachinePoolScope,
+				nodepools:             nodePools,
+				instancegroupmanagers: &fakeInstanceGroupManagers{managedInstances: tt.managedInstances},
+			}
+
+			if err := s.Reconcile(ctx); err != nil {
+				t.Fatalf("Service.Reconcile() error = %v", err)
+			}
+
+			gcpManagedMachinePool := managedMachinePoolScope.GCPManagedMachinePool
+			if tt.wantCreated {
+				nodePool, ok := nodePools.nodePools[fakeNodePoolFullName]
+				if !ok {
+					t.Fatalf("Service.Reconcile() did not create GKE node pool %s", fakeNodePoolFullName)
+				}
+				if nodePool.Version != "1.24.5" || nodePool.InitialNodeCount != 1 {
+					t.Errorf("Service.Reconcile() node pool = %v, want version 1.24.5 with 1 node per zone", nodePool)
+				}
+			}
+
+			switch {
+			case !tt.wantUpdate && len(nodePools.updates) > 0:
+				t.Errorf("Service.Reconcile() updates = %v, want none", nodePools.updates)
+			case tt.wantUpdate && (len(nodePools.updates) != 1 || nodePools.updates[0].NodeVersion != "1.24.5"):
+				t.Errorf("Service.Reconcile() updates = %v, want an upgrade to 1.24.5", nodePools.updates)
+			}
+
+			switch {
+			case tt.wantSize == nil && len(nodePools.sizes) > 0:
+				t.Errorf("Service.Reconcile() sizes = %v, want none", nodePools.sizes)
+			case tt.wantSize != nil && (len(nodePools.sizes) != 1 || nodePools.sizes[0] != *tt.wantSize):
+				t.Errorf("Service.Reconcile() sizes = %v, want %d", nodePools.sizes, *tt.wantSize)
+			}
+
+			if gcpManagedMachinePool.Status.Ready != tt.wantReady {
+				t.Errorf("Service.Reconcile() Ready = %v, want %v", gcpManagedMachinePool.Status.Ready, tt.wantReady)
+			}
+			if gcpManagedMachinePool.Status.Replicas != tt.wantReplicas {
+				t.Errorf("Service.Reconcile() Replicas = %d, want %d", gcpManagedMachinePool.Status.Replicas, tt.wantReplicas)
+			}
+			if len(gcpManagedMachinePool.Spec.ProviderIDList) != int(tt.wantReplicas) {
+				t.Errorf("Service.Reconcile() ProviderIDList = %v, want %d provider IDs", gcpManagedMachinePool.Spec.ProviderIDList, tt.wantReplicas)
+			}
+
+			if tt.wantReason == "" {
+				if !conditions.IsTrue(gcpManagedMachinePool, infrav1.GKENodePoolReadyCondition) {
+					t.Errorf("Service.Reconcile() GKENodePoolReady is not true")
+				}
+			} else if got := conditions.GetReason(gcpManagedMachinePool, infrav1.GKENodePoolReadyCondition); got != tt.wantReason {
+				t.Errorf("Service.Reconcile() GKENodePoolReady reason = %s, want %s", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []struct {
+		name       string
+		nodePools  map[string]*container.NodePool
+		wantReason string
+	}{
+		{
+			name: "GKE node pool running (should delete it)",
+			nodePools: map[string]*container.NodePool{
+				fakeNodePoolFullName: {Name: "my-pool", Status: nodePoolStatusRunning},
+			},
+			wantReason: infrav1.GKENodePoolDeletingReason,
+		},
+		{
+			name:       "GKE node pool does not exist (should be deleted)",
+			nodePools:  map[string]*container.NodePool{},
+			wantReason: clusterv1.DeletedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			managedMachinePoolScope := newManagedMachinePoolScope(t)
+			s := &Service{
+				scope:     managedMachinePoolScope,
+				nodepools: &fakeNodePools{nodePools: tt.nodePools},
+			}
+
+			if err := s.Delete(ctx); err != nil {
+				t.Fatalf("Service.Delete() error = %v", err)
+			}
+
+			if got := conditions.GetReason(managedMachinePoolScope.GCPManagedMachinePool, infrav1.GKENodePoolReadyCondition); got != tt.wantReason {
+				t.Errorf("Service.Delete() GKENodePoolReady reason = %s, want %s", got, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestProviderIDFromInstanceURL(t *testing.T) {
+	tests := []struct {
+		instanceURL string
+		want        string
+		wantOK      bool
+	}{
+		{
+			instanceURL: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-instance",
+			want:        "gce://my-proj/us-central1-a/my-instance",
+			wantOK:      true,
+		},
+		{
+			instanceURL: "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/instanceGroups/my-group",
+			wantOK:      false,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := providerIDFromInstanceURL(tt.instanceURL)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("providerIDFromInstanceURL(%q) = %q, %v, want %q, %v", tt.instanceURL, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type nodepoolsInterface interface {
+	Get(ctx context.Context, name string) (*container.NodePool, error)
+	Create(ctx context.Context, parent string, nodePool *container.NodePool) error
+	Update(ctx context.Context, name string, request *container.UpdateNodePoolRequest) error
+	SetAutoscaling(ctx context.Context, name string, autoscaling *container.NodePoolAutoscaling) error
+	SetSize(ctx context.Context, name string, nodeCount int64) error
+	Delete(ctx context.Context, name string) error
+}
+
+type instancegroupmanagersInterface interface {
+	ListManagedInstances(ctx context.Context, instanceGroupURL string) ([]*compute.ManagedInstance, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ManagedMachinePool
+}
+
+// Service implements GKE node pools reconciler.
+type Service struct {
+	scope                 Scope
+	nodepools             nodepoolsInterface
+	instancegroupmanagers instancegroupmanagersInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope: scope,
+		nodepools: &nodepoolsClient{
+			service: scope.ContainerService(),
+		},
+		instancegroupmanagers: &instancegroupmanagersClient{
+			service: scope.ComputeService(),
+		},
+	}
+}
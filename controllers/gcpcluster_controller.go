@@ -26,7 +26,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/bastions"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
@@ -34,6 +36,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -56,6 +59,8 @@ type GCPClusterReconciler struct {
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusteridentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *GCPClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := log.FromContext(ctx).WithValues("controller", "GCPCluster")
@@ -168,11 +173,13 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 
 	region, err := clusterScope.Cloud().Regions().Get(ctx, meta.GlobalKey(clusterScope.Region()))
 	if err != nil {
+		conditions.MarkFalse(clusterScope.GCPCluster, infrav1.FailureDomainsReadyCondition, gcperrors.ReasonForError(err, infrav1.FailureDomainsReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
 
 	zones, err := clusterScope.Cloud().Zones().List(ctx, filter.Regexp("region", region.SelfLink))
 	if err != nil {
+		conditions.MarkFalse(clusterScope.GCPCluster, infrav1.FailureDomainsReadyCondition, gcperrors.ReasonForError(err, infrav1.FailureDomainsReconciliationFailedReason), clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
 
@@ -194,25 +201,61 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	}
 
 	clusterScope.SetFailureDomains(failureDomains)
+	if len(failureDomains) == 0 {
+		conditions.MarkFalse(clusterScope.GCPCluster, infrav1.FailureDomainsReadyCondition, infrav1.FailureDomainsNotFoundReason, clusterv1.ConditionSeverityWarning,
+			"no zones of region %s match the requested failure domains", clusterScope.Region())
+	} else {
+		conditions.MarkTrue(clusterScope.GCPCluster, infrav1.FailureDomainsReadyCondition)
+	}
 
-	reconcilers := []cloud.Reconciler{
-		networks.New(clusterScope),
-		firewalls.New(clusterScope),
-		loadbalancers.New(clusterScope),
+	reconcilers := []clusterReconciler{
+		{infrav1.NetworkReadyCondition, infrav1.NetworkReconciliationFailedReason, networks.New(clusterScope)},
+		{infrav1.FirewallRulesReadyCondition, infrav1.FirewallRulesReconciliationFailedReason, firewalls.New(clusterScope)},
+	}
+	// The control plane endpoint of clusters without load balancer is provided by the user.
+	if clusterScope.LoadBalancerType() != infrav1.None {
+		reconcilers = append(reconcilers, clusterReconciler{infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerReconciliationFailedReason, loadbalancers.New(clusterScope)})
+	} else {
+		conditions.Delete(clusterScope.GCPCluster, infrav1.LoadBalancerReadyCondition)
 	}
+	reconcilers = append(reconcilers, clusterReconciler{infrav1.BastionHostReadyCondition, infrav1.BastionHostReconciliationFailedReason, bastions.New(clusterScope)})
+
+	return reconcileClusterServices(ctx, clusterScope, reconcilers)
+}
+
+// clusterReconciler pairs a cluster service with the condition reporting its state.
+type clusterReconciler struct {
+	condition  clusterv1.ConditionType
+	reason     string
+	reconciler cloud.Reconciler
+}
+
+// reconcileClusterServices reconciles the cluster services in order, marks their conditions and
+// marks the cluster ready once its control plane endpoint is known.
+func reconcileClusterServices(ctx context.Context, clusterScope *scope.ClusterScope, reconcilers []clusterReconciler) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
 
 	for _, r := range reconcilers {
-		if err := r.Reconcile(ctx); err != nil {
+		if err := r.reconciler.Reconcile(ctx); err != nil {
 			log.Error(err, "Reconcile error")
 			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
+			conditions.MarkFalse(clusterScope.GCPCluster, r.condition, gcperrors.ReasonForError(err, r.reason), clusterv1.ConditionSeverityError, err.Error())
 			return ctrl.Result{}, err
 		}
+
+		conditions.MarkTrue(clusterScope.GCPCluster, r.condition)
+	}
+
+	// Clusters without a bastion host do not report its condition.
+	if clusterScope.GCPCluster.Spec.Bastion == nil {
+		conditions.Delete(clusterScope.GCPCluster, infrav1.BastionHostReadyCondition)
 	}
 
 	controlPlaneEndpoint := clusterScope.ControlPlaneEndpoint()
 	if controlPlaneEndpoint.Host == "" {
 		log.Info("GCPCluster does not have control-plane endpoint yet. Reconciling")
 		record.Event(clusterScope.GCPCluster, "GCPClusterReconcile", "Waiting for control-plane endpoint")
+		conditions.MarkFalse(clusterScope.GCPCluster, infrav1.LoadBalancerReadyCondition, infrav1.WaitingForControlPlaneEndpointReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 
@@ -226,18 +269,31 @@ func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPCluster")
 
-	reconcilers := []cloud.Reconciler{
-		loadbalancers.New(clusterScope),
-		firewalls.New(clusterScope),
-		networks.New(clusterScope),
+	type clusterDeleter struct {
+		condition  clusterv1.ConditionType
+		reconciler cloud.Reconciler
 	}
+	reconcilers := []clusterDeleter{
+		{infrav1.BastionHostReadyCondition, bastions.New(clusterScope)},
+	}
+	// Clusters with a user-provided control plane endpoint have no load balancer to delete.
+	if clusterScope.LoadBalancerType() != infrav1.None {
+		reconcilers = append(reconcilers, clusterDeleter{infrav1.LoadBalancerReadyCondition, loadbalancers.New(clusterScope)})
+	}
+	reconcilers = append(reconcilers,
+		clusterDeleter{infrav1.FirewallRulesReadyCondition, firewalls.New(clusterScope)},
+		clusterDeleter{infrav1.NetworkReadyCondition, networks.New(clusterScope)},
+	)
 
 	for _, r := range reconcilers {
-		if err := r.Delete(ctx); err != nil {
+		if err := r.reconciler.Delete(ctx); err != nil {
 			log.Error(err, "Reconcile error")
 			record.Warnf(clusterScope.GCPCluster, "GCPClusterReconcile", "Reconcile error - %v", err)
+			conditions.MarkFalse(clusterScope.GCPCluster, r.condition, gcperrors.ReasonForError(err, clusterv1.DeletionFailedReason), clusterv1.ConditionSeverityWarning, err.Error())
 			return ctrl.Result{}, err
 		}
+
+		conditions.MarkFalse(clusterScope.GCPCluster, r.condition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
 	}
 
 	controllerutil.RemoveFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
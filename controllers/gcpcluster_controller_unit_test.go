@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeClusterService is a cluster service which returns err or, when it succeeds, runs onReconcile.
+type fakeClusterService struct {
+	err         error
+	onReconcile func()
+	reconciled  bool
+}
+
+func (f *fakeClusterService) Reconcile(_ context.Context) error {
+	f.reconciled = true
+	if f.err != nil {
+		return f.err
+	}
+	if f.onReconcile != nil {
+		f.onReconcile()
+	}
+	return nil
+}
+
+func (f *fakeClusterService) Delete(_ context.Context) error {
+	return nil
+}
+
+func TestReconcileClusterServices(t *testing.T) {
+	tests := []struct {
+		name           string
+		firewallsErr   error
+		setEndpoint    bool
+		wantErr        bool
+		wantResult     ctrl.Result
+		wantReady      bool
+		wantConditions map[clusterv1.ConditionType]string
+	}{
+		{
+			name:        "all services reconciled with an endpoint (should mark the cluster ready)",
+			setEndpoint: true,
+			wantResult:  ctrl.Result{},
+			wantReady:   true,
+			wantConditions: map[clusterv1.ConditionType]string{
+				infrav1.NetworkReadyCondition:       "",
+				infrav1.FirewallRulesReadyCondition: "",
+				infrav1.LoadBalancerReadyCondition:  "",
+			},
+		},
+		{
+			name:       "all services reconciled without an endpoint (should wait for the control plane endpoint)",
+			wantResult: ctrl.Result{RequeueAfter: 5 * time.Second},
+			wantConditions: map[clusterv1.ConditionType]string{
+				infrav1.NetworkReadyCondition:       "",
+				infrav1.FirewallRulesReadyCondition: "",
+				infrav1.LoadBalancerReadyCondition:  infrav1.WaitingForControlPlaneEndpointReason,
+			},
+		},
+		{
+			name:         "firewall rules fail (should mark their condition and stop)",
+			firewallsErr: errors.New("quota exceeded"),
+			setEndpoint:  true,
+			wantErr:      true,
+			wantResult:   ctrl.Result{},
+			wantConditions: map[clusterv1.ConditionType]string{
+				infrav1.NetworkReadyCondition:       "",
+				infrav1.FirewallRulesReadyCondition: infrav1.FirewallRulesReconciliationFailedReason,
+			},
+		},
+		{
+			name:         "firewall rules forbidden (should mark their condition with the reason of the GCP error)",
+			firewallsErr: &googleapi.Error{Code: http.StatusForbidden, Message: "permission denied"},
+			setEndpoint:  true,
+			wantErr:      true,
+			wantResult:   ctrl.Result{},
+			wantConditions: map[clusterv1.ConditionType]string{
+				infrav1.NetworkReadyCondition:       "",
+				infrav1.FirewallRulesReadyCondition: infrav1.PermissionDeniedReason,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+			gcpCluster := &infrav1.GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-cluster",
+					Namespace: "default",
+				},
+				Spec: infrav1.GCPClusterSpec{
+					Project: "my-proj",
+					Region:  "us-central1",
+				},
+			}
+			cluster := newCluster("my-cluster")
+			cluster.Spec.ClusterNetwork = &clusterv1.ClusterNetwork{}
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gcpCluster).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+				Client:      client,
+				Cluster:     cluster,
+				GCPCluster:  gcpCluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			loadbalancer := &fakeClusterService{}
+			if tt.setEndpoint {
+				loadbalancer.onReconcile = func() {
+					clusterScope.SetControlPlaneEndpoint(clusterv1.APIEndpoint{Host: "1.2.3.4"})
+				}
+			}
+			bastion := &fakeClusterService{}
+			reconcilers := []clusterReconciler{
+				{infrav1.NetworkReadyCondition, infrav1.NetworkReconciliationFailedReason, &fakeClusterService{}},
+				{infrav1.FirewallRulesReadyCondition, infrav1.FirewallRulesReconciliationFailedReason, &fakeClusterService{err: tt.firewallsErr}},
+				{infrav1.LoadBalancerReadyCondition, infrav1.LoadBalancerReconciliationFailedReason, loadbalancer},
+				{infrav1.BastionHostReadyCondition, infrav1.BastionHostReconciliationFailedReason, bastion},
+			}
+
+			result, err := reconcileClusterServices(context.TODO(), clusterScope, reconcilers)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(loadbalancer.reconciled).To(BeFalse())
+				g.Expect(bastion.reconciled).To(BeFalse())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(gcpCluster.Status.Ready).To(Equal(tt.wantReady))
+
+			for _, r := range reconcilers {
+				condition := conditions.Get(gcpCluster, r.condition)
+				wantReason, ok := tt.wantConditions[r.condition]
+				if !ok {
+					g.Expect(condition).To(BeNil(), "condition %s", r.condition)
+					continue
+				}
+				g.Expect(condition).NotTo(BeNil(), "condition %s", r.condition)
+				if wantReason == "" {
+					g.Expect(condition.Status).To(Equal(corev1.ConditionTrue), "condition %s", r.condition)
+				} else {
+					g.Expect(condition.Status).To(Equal(corev1.ConditionFalse), "condition %s", r.condition)
+					g.Expect(condition.Reason).To(Equal(wantReason), "condition %s", r.condition)
+				}
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -225,6 +226,7 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		return ctrl.Result{}, err
 	}
 
+	wasPreempted := conditions.GetReason(machineScope.GCPMachine, infrav1.InstanceReadyCondition) == infrav1.InstancePreemptedReason
 	if err := instances.New(machineScope).Reconcile(ctx); err != nil {
 		log.Error(err, "Error reconciling instance resources")
 		record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "Reconcile error - %v", err)
@@ -243,6 +245,21 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
 		machineScope.SetReady()
 		return ctrl.Result{}, nil
+	case infrav1.InstanceStatusStopping, infrav1.InstanceStatusStopped, infrav1.InstanceStatusTerminated:
+		if !machineScope.IsPreemptible() {
+			machineScope.SetFailureReason(capierrors.UpdateMachineError)
+			machineScope.SetFailureMessage(errors.Errorf("GCPMachine instance state %s is unexpected", instanceState))
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		// Reclaimed Spot and preemptible instances are left to the MachineHealthCheck remediation, keyed on the
+		// InstancePreempted reason of the InstanceReady condition, instead of marking the machine as failed.
+		if !wasPreempted {
+			log.Info("GCPMachine instance was preempted", "instance-id", *machineScope.GetInstanceID())
+			record.Warnf(machineScope.GCPMachine, "GCPMachineReconcile", "GCPMachine instance was preempted - instance-id: %s", *machineScope.GetInstanceID())
+		}
+		machineScope.SetNotReady()
+		return ctrl.Result{}, nil
 	default:
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
 		machineScope.SetFailureMessage(errors.Errorf("GCPMachine instance state %s is unexpected", instanceState))
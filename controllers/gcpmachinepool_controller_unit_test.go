@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGCPMachinePoolReconciler_reconcileWaiting(t *testing.T) {
+	tests := []struct {
+		name                string
+		infrastructureReady bool
+		dataSecretName      *string
+		wantCondition       clusterv1.ConditionType
+		wantReason          string
+	}{
+		{
+			name:                "cluster infrastructure not ready (should wait for the cluster)",
+			infrastructureReady: false,
+			dataSecretName:      pointer.String("my-pool-bootstrap"),
+			wantCondition:       infrav1.InstanceGroupReadyCondition,
+			wantReason:          infrav1.WaitingForClusterInfrastructureReason,
+		},
+		{
+			name:                "bootstrap data secret not set (should wait for the bootstrap data)",
+			infrastructureReady: true,
+			dataSecretName:      nil,
+			wantCondition:       infrav1.BootstrapDataReadyCondition,
+			wantReason:          infrav1.WaitingForBootstrapDataReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			g.Expect(expclusterv1.AddToScheme(scheme)).To(Succeed())
+
+			cluster := newCluster("my-cluster")
+			cluster.Status.InfrastructureReady = tt.infrastructureReady
+			gcpCluster := &infrav1.GCPCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-cluster",
+					Namespace: "default",
+				},
+				Spec: infrav1.GCPClusterSpec{
+					Project: "my-proj",
+					Region:  "us-central1",
+				},
+			}
+			machinePool := &expclusterv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-pool",
+					Namespace: "default",
+				},
+			}
+			machinePool.Spec.Template.Spec.Bootstrap.DataSecretName = tt.dataSecretName
+			gcpMachinePool := &infrav1.GCPMachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-pool",
+					Namespace: "default",
+				},
+			}
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gcpMachinePool).Build()
+			clusterScope, err := scope.NewClusterScope(scope.ClusterScopeParams{
+				GCPServices: scope.GCPServices{Compute: &compute.Service{}},
+				Client:      client,
+				Cluster:     cluster,
+				GCPCluster:  gcpCluster,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+				Client:         client,
+				ClusterGetter:  clusterScope,
+				Cluster:        cluster,
+				MachinePool:    machinePool,
+				GCPMachinePool: gcpMachinePool,
+			})
+			g.Expect(err).NotTo(HaveOccurred())
+
+			reconciler := &GCPMachinePoolReconciler{Client: client}
+			result, err := reconciler.reconcile(context.TODO(), machinePoolScope)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(ctrl.Result{}))
+
+			condition := conditions.Get(gcpMachinePool, tt.wantCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+		})
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// GCPManagedClusterReconciler reconciles a GCPManagedCluster object.
+type GCPManagedClusterReconciler struct {
+	client.Client
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusteridentities,verbs=get;list;watch
+
+func (r *GCPManagedClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := log.FromContext(ctx).WithValues("controller", "GCPManagedCluster")
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.GCPManagedCluster{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log)).
+		Watches(
+			&source.Kind{Type: &infrav1.GCPManagedControlPlane{}},
+			handler.EnqueueRequestsFromMapFunc(r.managedControlPlaneToManagedCluster(ctx)),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	if err = c.Watch(
+		&source.Kind{Type: &clusterv1.Cluster{}},
+		handler.EnqueueRequestsFromMapFunc(util.ClusterToInfrastructureMapFunc(ctx, infrav1.GroupVersion.WithKind("GCPManagedCluster"), mgr.GetClient(), &infrav1.GCPManagedCluster{})),
+		predicates.ClusterUnpaused(log),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for ready clusters")
+	}
+
+	return nil
+}
+
+func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	log := log.FromContext(ctx)
+	gcpManagedCluster := &infrav1.GCPManagedCluster{}
+	err := r.Get(ctx, req.NamespacedName, gcpManagedCluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("GCPManagedCluster resource not found or already deleted")
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "Unable to fetch GCPManagedCluster resource")
+		return ctrl.Result{}, err
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, gcpManagedCluster.ObjectMeta)
+	if err != nil {
+		log.Error(err, "Failed to get owner cluster")
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, gcpManagedCluster) {
+		log.Info("GCPManagedCluster of linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	managedClusterScope, err := scope.NewManagedClusterScope(scope.ManagedClusterScopeParams{
+		Client:            r.Client,
+		Cluster:           cluster,
+		GCPManagedCluster: gcpManagedCluster,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any GCPManagedCluster changes.
+	defer func() {
+		if err := managedClusterScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	// Handle deleted clusters
+	if !gcpManagedCluster.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, managedClusterScope)
+	}
+
+	// Handle non-deleted clusters
+	return r.reconcile(ctx, managedClusterScope)
+}
+
+func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, managedClusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GCPManagedCluster")
+
+	controllerutil.AddFinalizer(managedClusterScope.GCPManagedCluster, infrav1.ManagedClusterFinalizer)
+	if err := managedClusterScope.PatchObject(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := networks.New(managedClusterScope).Reconcile(ctx); err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(managedClusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
+		conditions.MarkFalse(managedClusterScope.GCPManagedCluster, infrav1.NetworkReadyCondition, infrav1.NetworkReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	conditions.MarkTrue(managedClusterScope.GCPManagedCluster, infrav1.NetworkReadyCondition)
+
+	// The GKE cluster is created once the network is ready, its endpoint is then copied from the control plane.
+	managedClusterScope.SetReady()
+	if err := r.reconcileControlPlaneEndpoint(ctx, managedClusterScope); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	record.Event(managedClusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+// reconcileControlPlaneEndpoint copies the endpoint of the GCPManagedControlPlane of the cluster, if any.
+func (r *GCPManagedClusterReconciler) reconcileControlPlaneEndpoint(ctx context.Context, managedClusterScope *scope.ManagedClusterScope) error {
+	controlPlaneRef := managedClusterScope.Cluster.Spec.ControlPlaneRef
+	if controlPlaneRef == nil || controlPlaneRef.Kind != "GCPManagedControlPlane" {
+		return nil
+	}
+
+	controlPlane := &infrav1.GCPManagedControlPlane{}
+	controlPlaneKey := client.ObjectKey{Namespace: managedClusterScope.Namespace(), Name: controlPlaneRef.Name}
+	if err := r.Get(ctx, controlPlaneKey, controlPlane); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "failed to get GCPManagedControlPlane")
+	}
+
+	if !controlPlane.Spec.ControlPlaneEndpoint.IsZero() {
+		managedClusterScope.SetControlPlaneEndpoint(controlPlane.Spec.ControlPlaneEndpoint)
+	}
+
+	return nil
+}
+
+func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, managedClusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling Delete GCPManagedCluster")
+
+	if err := networks.New(managedClusterScope).Delete(ctx); err != nil {
+		log.Error(err, "Reconcile error")
+		record.Warnf(managedClusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
+		conditions.MarkFalse(managedClusterScope.GCPManagedCluster, infrav1.NetworkReadyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	conditions.MarkFalse(managedClusterScope.GCPManagedCluster, infrav1.NetworkReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+	controllerutil.RemoveFinalizer(managedClusterScope.GCPManagedCluster, infrav1.ManagedClusterFinalizer)
+	record.Event(managedClusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+// managedControlPlaneToManagedCluster maps a GCPManagedControlPlane to the GCPManagedCluster of its Cluster.
+func (r *GCPManagedClusterReconciler) managedControlPlaneToManagedCluster(ctx context.Context) handler.MapFunc {
+	log := log.FromContext(ctx)
+	return func(o client.Object) []reconcile.Request {
+		controlPlane, ok := o.(*infrav1.GCPManagedControlPlane)
+		if !ok {
+			log.Error(errors.Errorf("expected a GCPManagedControlPlane but got a %T", o), "failed to map GCPManagedControlPlane")
+			return nil
+		}
+
+		if !controlPlane.DeletionTimestamp.IsZero() {
+			return nil
+		}
+
+		cluster, err := util.GetOwnerCluster(ctx, r.Client, controlPlane.ObjectMeta)
+		if err != nil || cluster == nil {
+			return nil
+		}
+
+		infrastructureRef := cluster.Spec.InfrastructureRef
+		if infrastructureRef == nil || infrastructureRef.Kind != "GCPManagedCluster" {
+			return nil
+		}
+
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: cluster.Namespace,
+					Name:      infrastructureRef.Name,
+				},
+			},
+		}
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/clusters"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// gkeProvisioningRequeueAfter is how often a GKE cluster is polled while it is created, updated or deleted.
+	gkeProvisioningRequeueAfter = 30 * time.Second
+
+	// gkeKubeconfigRequeueAfter is how often a running GKE cluster is reconciled to refresh the access token
+	// of its kubeconfig.
+	gkeKubeconfigRequeueAfter = 5 * time.Minute
+)
+
+// GCPManagedControlPlaneReconciler reconciles a GCPManagedControlPlane object.
+type GCPManagedControlPlaneReconciler struct {
+	client.Client
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+
+func (r *GCPManagedControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := log.FromContext(ctx).WithValues("controller", "GCPManagedControlPlane")
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.GCPManagedControlPlane{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	// Add a watch on clusterv1.Cluster object for unpause & infrastructure ready notifications.
+	if err = c.Watch(
+		&source.Kind{Type: &clusterv1.Cluster{}},
+		handler.EnqueueRequestsFromMapFunc(clusterToManagedControlPlane),
+		predicates.ClusterUnpausedAndInfrastructureReady(log),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for ready clusters")
+	}
+
+	return nil
+}
+
+func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	log := log.FromContext(ctx)
+	gcpManagedControlPlane := &infrav1.GCPManagedControlPlane{}
+	err := r.Get(ctx, req.NamespacedName, gcpManagedControlPlane)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("GCPManagedControlPlane resource not found or already deleted")
+			return ctrl.Result{}, nil
+		}
+
+		log.Error(err, "Unable to fetch GCPManagedControlPlane resource")
+		return ctrl.Result{}, err
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, gcpManagedControlPlane.ObjectMeta)
+	if err != nil {
+		log.Error(err, "Failed to get owner cluster")
+		return ctrl.Result{}, err
+	}
+	if cluster == nil {
+		log.Info("Cluster Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, gcpManagedControlPlane) {
+		log.Info("GCPManagedControlPlane or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+	if cluster.Spec.InfrastructureRef == nil {
+		log.Info("Cluster does not reference a GCPManagedCluster yet")
+		return ctrl.Result{}, nil
+	}
+
+	gcpManagedCluster := &infrav1.GCPManagedCluster{}
+	gcpManagedClusterKey := client.ObjectKey{
+		Namespace: gcpManagedControlPlane.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, gcpManagedClusterKey, gcpManagedCluster); err != nil {
+		log.Info("GCPManagedCluster is not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client:                 r.Client,
+		Cluster:                cluster,
+		GCPManagedCluster:      gcpManagedCluster,
+		GCPManagedControlPlane: gcpManagedControlPlane,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any GCPManagedControlPlane changes.
+	defer func() {
+		if err := managedControlPlaneScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	// Handle deleted control planes
+	if !gcpManagedControlPlane.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, managedControlPlaneScope)
+	}
+
+	// Handle non-deleted control planes
+	return r.reconcile(ctx, managedControlPlaneScope)
+}
+
+func (r *GCPManagedControlPlaneReconciler) reconcile(ctx context.Context, managedControlPlaneScope *scope.ManagedControlPlaneScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GCPManagedControlPlane")
+
+	controllerutil.AddFinalizer(managedControlPlaneScope.GCPManagedControlPlane, infrav1.ManagedControlPlaneFinalizer)
+	if err := managedControlPlaneScope.PatchObject(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !managedControlPlaneScope.GCPManagedCluster.Status.Ready {
+		log.Info("GCPManagedCluster is not ready yet")
+		conditions.MarkFalse(managedControlPlaneScope.GCPManagedControlPlane, infrav1.GKEControlPlaneReadyCondition, infrav1.WaitingForGKEClusterInfrastructureReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	if err := clusters.New(managedControlPlaneScope).Reconcile(ctx); err != nil {
+		log.Error(err, "Error reconciling GKE cluster")
+		record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
+		return ctrl.Result{}, err
+	}
+
+	// Poll the GKE cluster while it is created or updated.
+	if !conditions.IsTrue(managedControlPlaneScope.GCPManagedControlPlane, infrav1.GKEControlPlaneReadyCondition) {
+		log.Info("GKE cluster is not ready yet")
+		return ctrl.Result{RequeueAfter: gkeProvisioningRequeueAfter}, nil
+	}
+
+	record.Event(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconciled")
+	return ctrl.Result{RequeueAfter: gkeKubeconfigRequeueAfter}, nil
+}
+
+func (r *GCPManagedControlPlaneReconciler) reconcileDelete(ctx context.Context, managedControlPlaneScope *scope.ManagedControlPlaneScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling Delete GCPManagedControlPlane")
+
+	if err := clusters.New(managedControlPlaneScope).Delete(ctx); err != nil {
+		log.Error(err, "Error deleting GKE cluster")
+		record.Warnf(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconcile error - %v", err)
+		return ctrl.Result{}, err
+	}
+
+	// Poll the GKE cluster until it is gone.
+	if conditions.GetReason(managedControlPlaneScope.GCPManagedControlPlane, infrav1.GKEControlPlaneReadyCondition) != clusterv1.DeletedReason {
+		log.Info("GKE cluster is deleting")
+		return ctrl.Result{RequeueAfter: gkeProvisioningRequeueAfter}, nil
+	}
+
+	controllerutil.RemoveFinalizer(managedControlPlaneScope.GCPManagedControlPlane, infrav1.ManagedControlPlaneFinalizer)
+	record.Event(managedControlPlaneScope.GCPManagedControlPlane, "GCPManagedControlPlaneReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+// clusterToManagedControlPlane maps a Cluster to its GCPManagedControlPlane.
+func clusterToManagedControlPlane(o client.Object) []reconcile.Request {
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		return nil
+	}
+
+	controlPlaneRef := cluster.Spec.ControlPlaneRef
+	if controlPlaneRef == nil || controlPlaneRef.Kind != "GCPManagedControlPlane" {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      controlPlaneRef.Name,
+			},
+		},
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/nodepools"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	exputil "sigs.k8s.io/cluster-api/exp/util"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// GCPManagedMachinePoolReconciler reconciles a GCPManagedMachinePool object.
+type GCPManagedMachinePoolReconciler struct {
+	client.Client
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters;gcpmanagedcontrolplanes,verbs=get;list;watch
+
+func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := ctrl.LoggerFrom(ctx)
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1.GCPManagedMachinePool{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Watches(
+			&source.Kind{Type: &expclusterv1.MachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(exputil.MachinePoolToInfrastructureMapFunc(infrav1.GroupVersion.WithKind("GCPManagedMachinePool"), log)),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	clusterToObjectFunc, err := util.ClusterToObjectsMapper(r.Client, &infrav1.GCPManagedMachinePoolList{}, mgr.GetScheme())
+	if err != nil {
+		return errors.Wrap(err, "failed to create mapper for Cluster to GCPManagedMachinePools")
+	}
+
+	// Add a watch on clusterv1.Cluster object for unpause & ready notifications.
+	if err := c.Watch(
+		&source.Kind{Type: &clusterv1.Cluster{}},
+		handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
+		predicates.ClusterUnpausedAndInfrastructureReady(log),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for ready clusters")
+	}
+
+	return nil
+}
+
+func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	log := ctrl.LoggerFrom(ctx)
+	gcpManagedMachinePool := &infrav1.GCPManagedMachinePool{}
+	err := r.Get(ctx, req.NamespacedName, gcpManagedMachinePool)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	machinePool, err := exputil.GetOwnerMachinePool(ctx, r.Client, gcpManagedMachinePool.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		log.Info("MachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("machinePool", machinePool.Name)
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		log.Info("MachinePool is missing cluster label or cluster does not exist")
+
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, gcpManagedMachinePool) {
+		log.Info("GCPManagedMachinePool or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+	if cluster.Spec.InfrastructureRef == nil || cluster.Spec.ControlPlaneRef == nil {
+		log.Info("Cluster does not reference a GCPManagedCluster and a GCPManagedControlPlane yet")
+		return ctrl.Result{}, nil
+	}
+
+	gcpManagedCluster := &infrav1.GCPManagedCluster{}
+	gcpManagedClusterKey := client.ObjectKey{
+		Namespace: gcpManagedMachinePool.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, gcpManagedClusterKey, gcpManagedCluster); err != nil {
+		log.Info("GCPManagedCluster is not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	gcpManagedControlPlane := &infrav1.GCPManagedControlPlane{}
+	gcpManagedControlPlaneKey := client.ObjectKey{
+		Namespace: gcpManagedMachinePool.Namespace,
+		Name:      cluster.Spec.ControlPlaneRef.Name,
+	}
+	if err := r.Client.Get(ctx, gcpManagedControlPlaneKey, gcpManagedControlPlane); err != nil {
+		log.Info("GCPManagedControlPlane is not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	managedMachinePoolScope, err := scope.NewManagedMachinePoolScope(scope.ManagedMachinePoolScopeParams{
+		Client:                 r.Client,
+		Cluster:                cluster,
+		MachinePool:            machinePool,
+		GCPManagedCluster:      gcpManagedCluster,
+		GCPManagedControlPlane: gcpManagedControlPlane,
+		GCPManagedMachinePool:  gcpManagedMachinePool,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any GCPManagedMachinePool changes.
+	defer func() {
+		if err := managedMachinePoolScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	// Handle deleted machine pools
+	if !gcpManagedMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, managedMachinePoolScope)
+	}
+
+	// Handle non-deleted machine pools
+	return r.reconcile(ctx, managedMachinePoolScope)
+}
+
+func (r *GCPManagedMachinePoolReconciler) reconcile(ctx context.Context, managedMachinePoolScope *scope.ManagedMachinePoolScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GCPManagedMachinePool")
+
+	controllerutil.AddFinalizer(managedMachinePoolScope.GCPManagedMachinePool, infrav1.ManagedMachinePoolFinalizer)
+	if err := managedMachinePoolScope.PatchObject(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The node pools of a standard cluster are created along with the GKE cluster, the node pools added
+	// later are created once the GKE cluster is running.
+	if !managedMachinePoolScope.GCPManagedControlPlane.Status.Ready {
+		log.Info("GCPManagedControlPlane is not ready yet")
+		conditions.MarkFalse(managedMachinePoolScope.GCPManagedMachinePool, infrav1.GKENodePoolReadyCondition, infrav1.WaitingForGKEControlPlaneReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{RequeueAfter: gkeProvisioningRequeueAfter}, nil
+	}
+
+	if err := nodepools.New(managedMachinePoolScope).Reconcile(ctx); err != nil {
+		log.Error(err, "Error reconciling GKE node pool")
+		record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconcile error - %v", err)
+		return ctrl.Result{}, err
+	}
+
+	// Poll the GKE node pool while it is created, updated or resized.
+	if !conditions.IsTrue(managedMachinePoolScope.GCPManagedMachinePool, infrav1.GKENodePoolReadyCondition) {
+		log.Info("GKE node pool is not ready yet")
+		return ctrl.Result{RequeueAfter: gkeProvisioningRequeueAfter}, nil
+	}
+
+	record.Event(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+func (r *GCPManagedMachinePoolReconciler) reconcileDelete(ctx context.Context, managedMachinePoolScope *scope.ManagedMachinePoolScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling Delete GCPManagedMachinePool")
+
+	if err := nodepools.New(managedMachinePoolScope).Delete(ctx); err != nil {
+		log.Error(err, "Error deleting GKE node pool")
+		record.Warnf(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconcile error - %v", err)
+		return ctrl.Result{}, err
+	}
+
+	// Poll the GKE node pool until it is gone.
+	if conditions.GetReason(managedMachinePoolScope.GCPManagedMachinePool, infrav1.GKENodePoolReadyCondition) != clusterv1.DeletedReason {
+		log.Info("GKE node pool is deleting")
+		return ctrl.Result{RequeueAfter: gkeProvisioningRequeueAfter}, nil
+	}
+
+	controllerutil.RemoveFinalizer(managedMachinePoolScope.GCPManagedMachinePool, infrav1.ManagedMachinePoolFinalizer)
+	record.Event(managedMachinePoolScope.GCPManagedMachinePool, "GCPManagedMachinePoolReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
@@ -35,9 +35,12 @@ import (
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha4"
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/controllers"
+	capgfeature "sigs.k8s.io/cluster-api-provider-gcp/feature"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	"sigs.k8s.io/cluster-api-provider-gcp/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -56,26 +59,31 @@ func init() {
 	_ = infrav1alpha4.AddToScheme(scheme)
 	_ = infrav1beta1.AddToScheme(scheme)
 	_ = clusterv1.AddToScheme(scheme)
+	_ = expclusterv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 }
 
 var (
-	enableLeaderElection        bool
-	metricsAddr                 string
-	leaderElectionNamespace     string
-	watchNamespace              string
-	profilerAddress             string
-	healthAddr                  string
-	watchFilterValue            string
-	webhookCertDir              string
-	gcpClusterConcurrency       int
-	gcpMachineConcurrency       int
-	webhookPort                 int
-	reconcileTimeout            time.Duration
-	syncPeriod                  time.Duration
-	leaderElectionLeaseDuration time.Duration
-	leaderElectionRenewDeadline time.Duration
-	leaderElectionRetryPeriod   time.Duration
+	enableLeaderElection              bool
+	metricsAddr                       string
+	leaderElectionNamespace           string
+	watchNamespace                    string
+	profilerAddress                   string
+	healthAddr                        string
+	watchFilterValue                  string
+	webhookCertDir                    string
+	gcpClusterConcurrency             int
+	gcpMachineConcurrency             int
+	gcpMachinePoolConcurrency         int
+	gcpManagedClusterConcurrency      int
+	gcpManagedControlPlaneConcurrency int
+	gcpManagedMachinePoolConcurrency  int
+	webhookPort                       int
+	reconcileTimeout                  time.Duration
+	syncPeriod                        time.Duration
+	leaderElectionLeaseDuration       time.Duration
+	leaderElectionRenewDeadline       time.Duration
+	leaderElectionRetryPeriod         time.Duration
 )
 
 func main() {
@@ -145,6 +153,44 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "GCPCluster")
 		os.Exit(1)
 	}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		setupLog.Info("Enabling GCPMachinePool controller")
+		if err = (&controllers.GCPMachinePoolReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachinePoolConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GCPMachinePool")
+			os.Exit(1)
+		}
+	}
+	if feature.Gates.Enabled(capgfeature.GKE) {
+		setupLog.Info("Enabling GKE controllers")
+		if err = (&controllers.GCPManagedClusterReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpManagedClusterConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GCPManagedCluster")
+			os.Exit(1)
+		}
+		if err = (&controllers.GCPManagedControlPlaneReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpManagedControlPlaneConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GCPManagedControlPlane")
+			os.Exit(1)
+		}
+		if err = (&controllers.GCPManagedMachinePoolReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpManagedMachinePoolConcurrency}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GCPManagedMachinePool")
+			os.Exit(1)
+		}
+	}
 
 	if err = (&infrav1beta1.GCPCluster{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "GCPCluster")
@@ -154,6 +200,10 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "GCPClusterTemplate")
 		os.Exit(1)
 	}
+	if err = (&infrav1beta1.GCPClusterIdentity{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "GCPClusterIdentity")
+		os.Exit(1)
+	}
 	if err = (&infrav1beta1.GCPMachine{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "GCPMachine")
 		os.Exit(1)
@@ -162,6 +212,26 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "GCPMachineTemplate")
 		os.Exit(1)
 	}
+	if feature.Gates.Enabled(feature.MachinePool) {
+		if err = (&infrav1beta1.GCPMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GCPMachinePool")
+			os.Exit(1)
+		}
+	}
+	if feature.Gates.Enabled(capgfeature.GKE) {
+		if err = (&infrav1beta1.GCPManagedCluster{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GCPManagedCluster")
+			os.Exit(1)
+		}
+		if err = (&infrav1beta1.GCPManagedControlPlane{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GCPManagedControlPlane")
+			os.Exit(1)
+		}
+		if err = (&infrav1beta1.GCPManagedMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GCPManagedMachinePool")
+			os.Exit(1)
+		}
+	}
 
 	if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
 		setupLog.Error(err, "unable to create ready check")
@@ -257,6 +327,30 @@ func initFlags(fs *pflag.FlagSet) {
 		"Number of GCPMachines to process simultaneously",
 	)
 
+	fs.IntVar(&gcpMachinePoolConcurrency,
+		"gcpmachinepool-concurrency",
+		10,
+		"Number of GCPMachinePools to process simultaneously",
+	)
+
+	fs.IntVar(&gcpManagedClusterConcurrency,
+		"gcpmanagedcluster-concurrency",
+		10,
+		"Number of GCPManagedClusters to process simultaneously",
+	)
+
+	fs.IntVar(&gcpManagedControlPlaneConcurrency,
+		"gcpmanagedcontrolplane-concurrency",
+		10,
+		"Number of GCPManagedControlPlanes to process simultaneously",
+	)
+
+	fs.IntVar(&gcpManagedMachinePoolConcurrency,
+		"gcpmanagedmachinepool-concurrency",
+		10,
+		"Number of GCPManagedMachinePools to process simultaneously",
+	)
+
 	fs.DurationVar(&syncPeriod,
 		"sync-period",
 		10*time.Minute,
@@ -286,4 +380,6 @@ func initFlags(fs *pflag.FlagSet) {
 		reconciler.DefaultLoopTimeout,
 		"The maximum duration a reconcile loop can run (e.g. 90m)",
 	)
+
+	feature.MutableGates.AddFlag(fs)
 }